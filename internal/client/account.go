@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetAccount returns the account the token belongs to.
+func (c *Client) GetAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/account", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	account := Account{}
+	err = json.Unmarshal(body, &account)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
@@ -1,10 +1,12 @@
 package client
 
 import (
-	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const HostURL string = "https://api.clearscape.teradata.com/"
@@ -15,10 +17,15 @@ type Client struct {
 	Token      string
 }
 
-func NewClient(host, token string) (*Client, error) {
+func NewClient(host, token string, config TransportConfig) (*Client, error) {
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
 	c := Client{
 		HostURL:    HostURL,
-		HTTPClient: &http.Client{Timeout: 1000 * time.Second},
+		HTTPClient: &http.Client{Timeout: 1000 * time.Second, Transport: transport},
 		Token:      token,
 	}
 
@@ -35,8 +42,26 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	token := c.Token
 
 	req.Header.Set("Authorization", "Bearer "+token)
+
+	ctx := req.Context()
+	logHTTP := os.Getenv(HTTPLogEnvVar) != ""
+	if logHTTP {
+		ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv(HTTPLogEnvVar))
+		ctx = maskSubsystemLogContext(ctx, httpLogSubsystem, token)
+		logRequest(ctx, req)
+	}
+
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
+		if logHTTP {
+			tflog.SubsystemDebug(ctx, httpLogSubsystem, "ClearScape API request failed", map[string]interface{}{
+				"method":  req.Method,
+				"url":     req.URL.String(),
+				"latency": time.Since(start).String(),
+				"error":   err.Error(),
+			})
+		}
 		return nil, err
 	}
 	defer res.Body.Close()
@@ -44,8 +69,11 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if logHTTP {
+		logResponse(ctx, req, res, body, time.Since(start))
+	}
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, &APIError{StatusCode: res.StatusCode, Body: string(RedactJSON(body))}
 	}
 	return body, err
 }
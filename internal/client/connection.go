@@ -0,0 +1,112 @@
+package client
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultVantagePort is the port of the Vantage SQL engine when the service
+// URL does not specify one.
+const DefaultVantagePort = 1025
+
+// Connection holds the settings needed to connect to the Vantage SQL engine
+// and the Jupyter service of an environment.
+type Connection struct {
+	Host         string
+	Port         int64
+	User         string
+	Password     string
+	JupyterURL   string
+	JupyterToken string
+}
+
+// Service returns the first service whose name contains the given name,
+// compared case-insensitively.
+func (e *Environment) Service(name string) *Service {
+	for i, service := range e.Services {
+		if strings.Contains(strings.ToLower(service.Name), strings.ToLower(name)) {
+			return &e.Services[i]
+		}
+	}
+	return nil
+}
+
+// Credential returns the value of the first credential whose name matches
+// one of the given names, compared case-insensitively.
+func (s *Service) Credential(names ...string) string {
+	for _, cred := range s.Credentials {
+		for _, name := range names {
+			if strings.EqualFold(cred.Name, name) {
+				return cred.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Connection derives the Vantage and Jupyter connection settings from the
+// services of the environment. The host defaults to the DNS name of the
+// environment and the port to DefaultVantagePort.
+func (e *Environment) Connection() Connection {
+	conn := Connection{
+		Host: e.DNSName,
+		Port: DefaultVantagePort,
+	}
+	if conn.Host == "" {
+		conn.Host = e.IP
+	}
+
+	if vantage := e.Service("vantage"); vantage != nil {
+		host, port := splitHostPort(vantage.URL)
+		if host != "" {
+			conn.Host = host
+		}
+		if port != 0 {
+			conn.Port = port
+		}
+		conn.User = vantage.Credential("username", "user")
+		conn.Password = vantage.Credential("password")
+	}
+
+	if jupyter := e.Service("jupyter"); jupyter != nil {
+		conn.JupyterURL = jupyter.URL
+		conn.JupyterToken = jupyter.Credential("token")
+		if conn.JupyterToken == "" {
+			if u, err := url.Parse(jupyter.URL); err == nil {
+				conn.JupyterToken = u.Query().Get("token")
+			}
+		}
+	}
+
+	return conn
+}
+
+// splitHostPort extracts the host and port from a service URL, which may be
+// a full URL, a host:port pair or a bare host name.
+func splitHostPort(raw string) (string, int64) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", 0
+	}
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", 0
+		}
+		raw = u.Host
+	}
+
+	host, portStr, err := net.SplitHostPort(raw)
+	if err != nil {
+		return strings.TrimSuffix(raw, "/"), 0
+	}
+
+	port, err := strconv.ParseInt(portStr, 10, 64)
+	if err != nil {
+		return host, 0
+	}
+	return host, port
+}
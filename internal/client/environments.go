@@ -2,14 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
-func (c *Client) GetEnvironments() (*[]Environment, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/environments", c.HostURL), nil)
+func (c *Client) GetEnvironments(ctx context.Context) (*[]Environment, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/environments", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -26,17 +26,16 @@ func (c *Client) GetEnvironments() (*[]Environment, error) {
 	return &environments, nil
 }
 
-func (c *Client) CreateEnvironment(env EnvironmentCreateRequest) (*Environment, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/environments", c.HostURL), nil)
+func (c *Client) CreateEnvironment(ctx context.Context, env EnvironmentCreateRequest) (*Environment, error) {
+	reqbody, err := json.Marshal(env)
 	if err != nil {
 		return nil, err
 	}
 
-	reqbody, err := json.Marshal(env)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/environments", c.HostURL), bytes.NewReader(reqbody))
 	if err != nil {
 		return nil, err
 	}
-	req.Body = io.NopCloser(bytes.NewReader(reqbody))
 	req.Header.Set("Content-Type", "application/json")
 
 	body, err := c.doRequest(req)
@@ -51,8 +50,8 @@ func (c *Client) CreateEnvironment(env EnvironmentCreateRequest) (*Environment,
 	return &environment, nil
 }
 
-func (c *Client) GetEnvironment(envName string) (*Environment, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), nil)
+func (c *Client) GetEnvironment(ctx context.Context, envName string) (*Environment, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), nil)
 
 	if err != nil {
 		return nil, err
@@ -70,19 +69,41 @@ func (c *Client) GetEnvironment(envName string) (*Environment, error) {
 	return &environment, nil
 }
 
-func (c *Client) UpdateEnvironment(envName string, operation string) (*Environment, error) {
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), nil)
+func (c *Client) UpdateEnvironment(ctx context.Context, envName string, operation string) (*Environment, error) {
+	return c.patchEnvironment(ctx, envName, EnvironmentUpdateRequest{Operation: operation})
+}
+
+// ChangePassword rotates the password of the Vantage database of the
+// environment without recreating it.
+func (c *Client) ChangePassword(ctx context.Context, envName string, password string) (*Environment, error) {
+	return c.patchEnvironment(ctx, envName, EnvironmentUpdateRequest{
+		Operation: OperationChangePassword,
+		Password:  password,
+	})
+}
+
+// UpdateTags replaces the tags of the environment.
+func (c *Client) UpdateTags(ctx context.Context, envName string, tags map[string]string) (*Environment, error) {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return c.patchEnvironment(ctx, envName, EnvironmentUpdateRequest{
+		Operation: OperationUpdateTags,
+		Tags:      tags,
+	})
+}
 
+func (c *Client) patchEnvironment(ctx context.Context, envName string, update EnvironmentUpdateRequest) (*Environment, error) {
+	postBody, err := json.Marshal(update)
 	if err != nil {
 		return nil, err
 	}
-	postBody, err := json.Marshal(map[string]string{
-		"operation": operation,
-	})
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), bytes.NewReader(postBody))
+
 	if err != nil {
 		return nil, err
 	}
-	req.Body = io.NopCloser(bytes.NewReader(postBody))
 	req.Header.Set("Content-Type", "application/json")
 
 	body, err := c.doRequest(req)
@@ -97,8 +118,8 @@ func (c *Client) UpdateEnvironment(envName string, operation string) (*Environme
 	return &environment, nil
 }
 
-func (c *Client) DeleteEnvironment(envName string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), nil)
+func (c *Client) DeleteEnvironment(ctx context.Context, envName string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/environments/%s", c.HostURL, envName), nil)
 
 	if err != nil {
 		return err
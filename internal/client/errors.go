@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// APIError is returned when the ClearScape API answers with a status other
+// than 200 OK. Body is redacted.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an API error for a missing environment.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsAlreadyDeleting reports whether err is an API error rejecting a delete
+// because the environment is already being deleted. The API uses the same
+// statuses for other conflicts, so only the message tells them apart.
+func IsAlreadyDeleting(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusConflict, http.StatusBadRequest, http.StatusUnprocessableEntity:
+		body := strings.ToLower(apiErr.Body)
+		return strings.Contains(body, "deleting") || strings.Contains(body, "being deleted")
+	}
+	return false
+}
+
+// IsAmbiguous reports whether err leaves it unknown if the API applied the
+// request: timeouts, connection resets and server side errors.
+func IsAmbiguous(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIsAlreadyDeleting(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"conflict deleting": {
+			err:  &APIError{StatusCode: http.StatusConflict, Body: `{"message":"Environment env1 is DELETING"}`},
+			want: true,
+		},
+		"conflict being deleted": {
+			err:  &APIError{StatusCode: http.StatusConflict, Body: `{"message":"environment is being deleted"}`},
+			want: true,
+		},
+		"bad request deleting": {
+			err:  &APIError{StatusCode: http.StatusBadRequest, Body: `{"message":"environment is deleting"}`},
+			want: true,
+		},
+		"unprocessable deleting": {
+			err:  &APIError{StatusCode: http.StatusUnprocessableEntity, Body: `{"message":"environment is deleting"}`},
+			want: true,
+		},
+		"wrapped": {
+			err:  fmt.Errorf("deleting env1: %w", &APIError{StatusCode: http.StatusConflict, Body: `{"message":"env1 is deleting"}`}),
+			want: true,
+		},
+		"conflict operation in progress": {
+			err: &APIError{StatusCode: http.StatusConflict, Body: `{"message":"another operation is in progress"}`},
+		},
+		"conflict name taken": {
+			err: &APIError{StatusCode: http.StatusConflict, Body: `{"message":"name already taken"}`},
+		},
+		"conflict without body": {
+			err: &APIError{StatusCode: http.StatusConflict},
+		},
+		"server error deleting": {
+			err: &APIError{StatusCode: http.StatusInternalServerError, Body: `{"message":"failed while deleting"}`},
+		},
+		"not an api error": {
+			err: errors.New("environment is deleting"),
+		},
+		"nil": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsAlreadyDeleting(tt.err); got != tt.want {
+				t.Errorf("IsAlreadyDeleting(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
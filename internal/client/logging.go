@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// HTTPLogEnvVar enables logging of every ClearScape API request and response
+// when set to a log level such as DEBUG or TRACE.
+const HTTPLogEnvVar = "TF_LOG_PROVIDER_CLEARSCAPE_HTTP"
+
+const httpLogSubsystem = "clearscape_http"
+
+// requestIDHeaders are checked in order for the identifier of an API call
+// that Teradata support can correlate with server side logs.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Correlation-Id"}
+
+func logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			if body, err := io.ReadAll(reader); err == nil && len(body) > 0 {
+				fields["body"] = string(RedactJSON(body))
+			}
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "ClearScape API request", fields)
+}
+
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, latency time.Duration) {
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"url":        req.URL.String(),
+		"status":     res.StatusCode,
+		"latency":    latency.String(),
+		"request_id": requestID(res.Header),
+		"headers":    redactHeaders(res.Header),
+	}
+
+	if len(body) > 0 {
+		fields["body"] = string(RedactJSON(body))
+	}
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "ClearScape API response", fields)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if isSensitiveKey(name) || name == "Cookie" || name == "Set-Cookie" {
+			headers[name] = Redacted
+			continue
+		}
+		headers[name] = string(redactPatterns([]byte(strings.Join(values, ", "))))
+	}
+	return headers
+}
+
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestDoRequestLogging(t *testing.T) {
+	t.Setenv(HTTPLogEnvVar, "DEBUG")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"env1","state":"RUNNING","services":[{"name":"vantage","credentials":[{"name":"username","value":"demo_user"},{"name":"password","value":"created-password"}]},{"name":"jupyter","credentials":[{"name":"token","value":"jupyter-token"}]}]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "api-token", TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err = c.CreateEnvironment(ctx, EnvironmentCreateRequest{Name: "env1", Region: "us-central", Password: "created-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"api-token", "created-password", "demo_user", "jupyter-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output contains %q:\n%s", secret, logs)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, entry := range entries {
+		messages = append(messages, entry["@message"].(string))
+	}
+	if strings.Join(messages, ",") != "ClearScape API request,ClearScape API response" {
+		t.Fatalf("logged messages = %v, want the request and the response", messages)
+	}
+	if entries[1]["request_id"] != "req-42" {
+		t.Errorf("request_id = %v, want req-42", entries[1]["request_id"])
+	}
+}
+
+func TestDoRequestLoggingDisabled(t *testing.T) {
+	t.Setenv(HTTPLogEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "api-token", TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := c.GetEnvironments(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("logged without %s set:\n%s", HTTPLogEnvVar, output.String())
+	}
+}
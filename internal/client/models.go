@@ -12,18 +12,59 @@ type Service struct {
 }
 
 type Environment struct {
-	Name     string    `json:"name"`
-	Region   string    `json:"region"`
-	State    string    `json:"state"`
-	IP       string    `json:"ip"`
-	DNSName  string    `json:"dnsName"`
-	Owner    string    `json:"owner"`
-	Type     string    `json:"type"`
-	Services []Service `json:"services"`
+	Name     string            `json:"name"`
+	Region   string            `json:"region"`
+	State    string            `json:"state"`
+	IP       string            `json:"ip"`
+	DNSName  string            `json:"dnsName"`
+	Owner    string            `json:"owner"`
+	Type     string            `json:"type"`
+	Services []Service         `json:"services"`
+	Tags     map[string]string `json:"tags,omitempty"`
+
+	// CreatedAt and UpdatedAt are RFC 3339 timestamps.
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
 }
 
 type EnvironmentCreateRequest struct {
-	Name     string `json:"name"`
-	Region   string `json:"region"`
-	Password string `json:"password"`
+	Name     string            `json:"name"`
+	Region   string            `json:"region"`
+	Password string            `json:"password"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+// Environment states reported by the ClearScape API.
+const (
+	StateRunning = "RUNNING"
+	StateStopped = "STOPPED"
+	StateFailed  = "FAILED"
+	StateError   = "ERROR"
+)
+
+// Operations accepted by UpdateEnvironment.
+const (
+	OperationStart          = "start"
+	OperationStop           = "stop"
+	OperationChangePassword = "changePassword"
+	OperationUpdateTags     = "updateTags"
+)
+
+type EnvironmentUpdateRequest struct {
+	Operation string            `json:"operation"`
+	Password  string            `json:"password,omitempty"`
+	Tags      map[string]string `json:"tags,omitzero"`
+}
+
+// Quota holds the limits of the account.
+type Quota struct {
+	// MaxEnvironments is the number of environments the account may have at
+	// the same time, running or stopped.
+	MaxEnvironments int `json:"maxEnvironments"`
+}
+
+// Account describes the account the token belongs to.
+type Account struct {
+	// Email is the owner reported for environments of the account.
+	Email string `json:"email"`
 }
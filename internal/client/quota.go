@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetQuota returns the limits of the account the token belongs to.
+func (c *Client) GetQuota(ctx context.Context) (*Quota, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/quota", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	quota := Quota{}
+	err = json.Unmarshal(body, &quota)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Redacted replaces any secret removed from logs, errors and bodies.
+const Redacted = "***"
+
+// sensitiveKeys are the log field keys and JSON object keys whose values are
+// always treated as secrets, regardless of their content.
+var sensitiveKeys = []string{
+	"password",
+	"value",
+	"token",
+	"authorization",
+	"clearscape_token",
+}
+
+// sensitivePatterns match secrets that can appear inside otherwise harmless
+// strings, such as an Authorization header echoed in an error message.
+var sensitivePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`),
+}
+
+// MaskLogContext registers the sensitive field keys, value patterns and the
+// given secret strings on the context so that tflog never writes them.
+func MaskLogContext(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveKeys...)
+	ctx = tflog.MaskLogRegexes(ctx, sensitivePatterns...)
+
+	if values := nonEmpty(secrets); len(values) > 0 {
+		ctx = tflog.MaskLogStrings(ctx, values...)
+	}
+
+	return ctx
+}
+
+func maskSubsystemLogContext(ctx context.Context, subsystem string, secrets ...string) context.Context {
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, sensitiveKeys...)
+	ctx = tflog.SubsystemMaskLogRegexes(ctx, subsystem, sensitivePatterns...)
+
+	if values := nonEmpty(secrets); len(values) > 0 {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, subsystem, values...)
+	}
+
+	return ctx
+}
+
+// Secrets returns the credential values of all services of the environment.
+func (e *Environment) Secrets() []string {
+	var secrets []string
+	for _, service := range e.Services {
+		for _, cred := range service.Credentials {
+			secrets = append(secrets, cred.Value)
+		}
+	}
+	return nonEmpty(secrets)
+}
+
+// RedactJSON returns a copy of the JSON document with the values of all
+// sensitive keys replaced. Bodies that are not valid JSON are returned with
+// only the sensitive patterns replaced.
+func RedactJSON(body []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return redactPatterns(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return redactPatterns(body)
+	}
+	return redacted
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if isSensitiveKey(k) {
+				if _, ok := child.(string); ok {
+					t[k] = Redacted
+					continue
+				}
+			}
+			t[k] = redactValue(child)
+		}
+		return t
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactValue(child)
+		}
+		return t
+	case string:
+		return string(redactPatterns([]byte(t)))
+	default:
+		return t
+	}
+}
+
+func redactPatterns(b []byte) []byte {
+	for _, re := range sensitivePatterns {
+		b = re.ReplaceAll(b, []byte(Redacted))
+	}
+	return b
+}
+
+func isSensitiveKey(key string) bool {
+	for _, k := range sensitiveKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := map[string]struct {
+		body    string
+		secrets []string
+		keep    []string
+	}{
+		"create request": {
+			body:    `{"name":"env1","region":"us-central","password":"s3cret!"}`,
+			secrets: []string{"s3cret!"},
+			keep:    []string{"env1", "us-central"},
+		},
+		"service credentials": {
+			body:    `{"name":"env1","services":[{"name":"vantage","credentials":[{"name":"username","value":"demo_user"},{"name":"password","value":"p@ss"}]},{"name":"jupyter","credentials":[{"name":"token","value":"jtok"}]}]}`,
+			secrets: []string{"demo_user", "p@ss", "jtok"},
+			keep:    []string{"env1", "vantage", "jupyter"},
+		},
+		"mixed case keys": {
+			body:    `{"Password":"a1","TOKEN":"b2"}`,
+			secrets: []string{"a1", "b2"},
+		},
+		"bearer token in message": {
+			body:    `{"message":"invalid header Authorization: Bearer abc.def-123"}`,
+			secrets: []string{"abc.def-123"},
+			keep:    []string{"invalid header"},
+		},
+		"invalid JSON": {
+			body:    `upstream error: Bearer abc.def-123`,
+			secrets: []string{"abc.def-123"},
+			keep:    []string{"upstream error"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := string(RedactJSON([]byte(test.body)))
+
+			for _, secret := range test.secrets {
+				if strings.Contains(got, secret) {
+					t.Errorf("redacted body %s contains %q", got, secret)
+				}
+			}
+			for _, value := range test.keep {
+				if !strings.Contains(got, value) {
+					t.Errorf("redacted body %s lost %q", got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactJSONKeepsStructure(t *testing.T) {
+	got := RedactJSON([]byte(`{"password":{"nested":"x"},"count":3,"tags":{"team":"a"}}`))
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("redacted body is not JSON: %s", err)
+	}
+	if doc["count"] != float64(3) {
+		t.Errorf("count = %v, want 3", doc["count"])
+	}
+	if tags, _ := doc["tags"].(map[string]interface{}); tags["team"] != "a" {
+		t.Errorf("tags = %v, want team=a", doc["tags"])
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	header.Set("Cookie", "session=abc")
+	header.Set("Set-Cookie", "session=def")
+	header.Set("X-Debug", "forwarded Bearer other-token")
+	header.Set("Content-Type", "application/json")
+
+	got := redactHeaders(header)
+
+	for _, name := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if got[name] != Redacted {
+			t.Errorf("%s = %q, want %q", name, got[name], Redacted)
+		}
+	}
+	if strings.Contains(got["X-Debug"], "other-token") {
+		t.Errorf("X-Debug = %q, want the bearer token redacted", got["X-Debug"])
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q, want it unchanged", got["Content-Type"])
+	}
+}
+
+func TestMaskLogContext(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = MaskLogContext(ctx, "api-token-1", "", "db-password-2")
+
+	tflog.Info(ctx, "Creating environment with password db-password-2", map[string]interface{}{
+		"password": "field-password",
+		"value":    "credential-value",
+		"token":    "field-token",
+		"name":     "env1",
+	})
+	tflog.Debug(ctx, "Request failed: Authorization: Bearer api-token-1")
+	tflog.Trace(ctx, "Retrying", map[string]interface{}{"error": "bad token api-token-1"})
+
+	logs := output.String()
+	for _, secret := range []string{"api-token-1", "db-password-2", "field-password", "credential-value", "field-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output contains %q:\n%s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "env1") {
+		t.Errorf("log output lost non-sensitive fields:\n%s", logs)
+	}
+}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testKeyPair returns a self-signed certificate and its key, PEM encoded.
+func testKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "clearscape-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := testKeyPair(t)
+	_, otherKeyPEM := testKeyPair(t)
+	certFile := writeTestFile(t, "client.crt", certPEM)
+	keyFile := writeTestFile(t, "client.key", keyPEM)
+
+	tests := map[string]struct {
+		config    TransportConfig
+		wantErr   string
+		wantCAs   bool
+		wantCerts int
+	}{
+		"defaults": {
+			config: TransportConfig{},
+		},
+		"ca pem": {
+			config:  TransportConfig{CACertPEM: certPEM},
+			wantCAs: true,
+		},
+		"ca file": {
+			config:  TransportConfig{CACertFile: certFile},
+			wantCAs: true,
+		},
+		"invalid ca pem": {
+			config:  TransportConfig{CACertPEM: "not a certificate"},
+			wantErr: "no valid certificates found in CA certificate PEM",
+		},
+		"invalid ca file": {
+			config:  TransportConfig{CACertFile: keyFile},
+			wantErr: "no valid certificates found in " + keyFile,
+		},
+		"missing ca file": {
+			config:  TransportConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: "reading CA certificate file",
+		},
+		"client pem": {
+			config:    TransportConfig{ClientCert: certPEM, ClientKey: keyPEM},
+			wantCerts: 1,
+		},
+		"client pem with leading whitespace": {
+			config:    TransportConfig{ClientCert: "\n  " + certPEM, ClientKey: keyPEM},
+			wantCerts: 1,
+		},
+		"client files": {
+			config:    TransportConfig{ClientCert: certFile, ClientKey: keyFile},
+			wantCerts: 1,
+		},
+		"client cert without client key": {
+			config:  TransportConfig{ClientCert: certPEM},
+			wantErr: "both a client certificate and a client key are required",
+		},
+		"client key without client cert": {
+			config:  TransportConfig{ClientKey: keyPEM},
+			wantErr: "both a client certificate and a client key are required",
+		},
+		"missing client cert file": {
+			config:  TransportConfig{ClientCert: filepath.Join(t.TempDir(), "missing.crt"), ClientKey: keyPEM},
+			wantErr: "reading client certificate",
+		},
+		"missing client key file": {
+			config:  TransportConfig{ClientCert: certPEM, ClientKey: filepath.Join(t.TempDir(), "missing.key")},
+			wantErr: "reading client key",
+		},
+		"mismatched client key": {
+			config:  TransportConfig{ClientCert: certPEM, ClientKey: otherKeyPEM},
+			wantErr: "loading client certificate",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig, err := newTLSConfig(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tlsConfig.InsecureSkipVerify {
+				t.Error("InsecureSkipVerify enabled without being requested")
+			}
+			if (tlsConfig.RootCAs != nil) != tt.wantCAs {
+				t.Errorf("RootCAs set = %t, want %t", tlsConfig.RootCAs != nil, tt.wantCAs)
+			}
+			if len(tlsConfig.Certificates) != tt.wantCerts {
+				t.Errorf("got %d client certificates, want %d", len(tlsConfig.Certificates), tt.wantCerts)
+			}
+		})
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	tests := map[string]struct {
+		noProxyVar string
+		noProxy    string
+		target     string
+		wantProxy  string
+	}{
+		"proxied": {
+			noProxyVar: "NO_PROXY",
+			noProxy:    "internal.example",
+			target:     "https://api.clearscape.example/environments",
+			wantProxy:  "http://proxy.example:3128",
+		},
+		"bypassed by NO_PROXY": {
+			noProxyVar: "NO_PROXY",
+			noProxy:    "internal.example",
+			target:     "https://api.internal.example/environments",
+		},
+		"bypassed by no_proxy": {
+			noProxyVar: "no_proxy",
+			noProxy:    ".internal.example",
+			target:     "https://api.internal.example/environments",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("NO_PROXY", "")
+			t.Setenv("no_proxy", "")
+			t.Setenv(tt.noProxyVar, tt.noProxy)
+
+			transport, err := newTransport(TransportConfig{ProxyURL: "http://proxy.example:3128", InsecureSkipVerify: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !transport.TLSClientConfig.InsecureSkipVerify {
+				t.Error("TLS configuration not applied to the transport")
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxy, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ""
+			if proxy != nil {
+				got = proxy.String()
+			}
+			if got != tt.wantProxy {
+				t.Errorf("proxy = %q, want %q", got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestNewTransportErrors(t *testing.T) {
+	tests := map[string]struct {
+		config  TransportConfig
+		wantErr string
+	}{
+		"invalid proxy url": {
+			config:  TransportConfig{ProxyURL: "http://[::1"},
+			wantErr: "invalid proxy URL",
+		},
+		"invalid tls config": {
+			config:  TransportConfig{ClientCert: "client.crt"},
+			wantErr: "both a client certificate and a client key are required",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newTransport(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
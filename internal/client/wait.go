@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PollInterval is the delay between two checks of an environment while
+// waiting for it to change state.
+var PollInterval = 10 * time.Second
+
+// WaitForState polls the environment until it reports the target state, the
+// context is done, the environment fails or the API returns an error. The
+// last environment seen is returned along with any error.
+func (c *Client) WaitForState(ctx context.Context, envName string, target string) (*Environment, error) {
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		env, err := c.GetEnvironment(ctx, envName)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(env.State, target) {
+			return env, nil
+		}
+		if strings.EqualFold(env.State, StateFailed) || strings.EqualFold(env.State, StateError) {
+			return env, fmt.Errorf("environment %s is %s while waiting for it to be %s", envName, env.State, target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return env, fmt.Errorf("timed out waiting for environment %s to be %s, last state %s: %w", envName, target, env.State, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForDeletion polls the environment until the API no longer finds it or
+// the context is done.
+func (c *Client) WaitForDeletion(ctx context.Context, envName string) error {
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		env, err := c.GetEnvironment(ctx, envName)
+		if IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for environment %s to be deleted, last state %s: %w", envName, env.State, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &connectionStringFunction{}
+
+// Connection string formats supported by the connection_string function.
+const (
+	connectionFormatJDBC        = "jdbc"
+	connectionFormatTeradataSQL = "teradatasql"
+	connectionFormatODBC        = "odbc"
+	connectionFormatSQLAlchemy  = "sqlalchemy"
+	connectionFormatTeradataML  = "teradataml"
+)
+
+var connectionFormats = []string{
+	connectionFormatJDBC,
+	connectionFormatTeradataSQL,
+	connectionFormatODBC,
+	connectionFormatSQLAlchemy,
+	connectionFormatTeradataML,
+}
+
+func ConnectionStringFunction() function.Function {
+	return &connectionStringFunction{}
+}
+
+// connectionStringFunction builds client connection strings for the Vantage
+// SQL engine of an environment.
+type connectionStringFunction struct{}
+
+// Metadata returns the function name.
+func (f *connectionStringFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "connection_string"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *connectionStringFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a connection string for the Vantage SQL engine of an environment",
+		MarkdownDescription: "Builds a connection string for the Vantage SQL engine of an environment from its `dnsname` and `services`. " +
+			"Supported formats are `jdbc` (Teradata JDBC URL), `teradatasql` (JSON connection parameters for the teradatasql driver), " +
+			"`odbc` (ODBC connection string), `sqlalchemy` (`teradatasql://` URL) and `teradataml` (JSON parameters for `create_context`).",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "environment",
+				MarkdownDescription: "A `teradata-clearscape_environment` resource or an element of the `teradata-clearscape_environments` data source.",
+			},
+			function.StringParameter{
+				Name:                "format",
+				MarkdownDescription: "One of `jdbc`, `teradatasql`, `odbc`, `sqlalchemy` or `teradataml`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the connection string.
+func (f *connectionStringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var environment types.Dynamic
+	var format string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &environment, &format))
+	if resp.Error != nil {
+		return
+	}
+
+	env, err := environmentFromValue(environment.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, err := connectionString(env.Connection(), format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+func connectionString(conn client.Connection, format string) (string, error) {
+	port := strconv.FormatInt(conn.Port, 10)
+
+	switch strings.ToLower(format) {
+	case connectionFormatJDBC:
+		params := []string{"DBS_PORT=" + port}
+		if conn.User != "" {
+			params = append(params, "USER="+jdbcValue(conn.User))
+		}
+		if conn.Password != "" {
+			params = append(params, "PASSWORD="+jdbcValue(conn.Password))
+		}
+		return fmt.Sprintf("jdbc:teradata://%s/%s", conn.Host, strings.Join(params, ",")), nil
+
+	case connectionFormatTeradataSQL:
+		return jsonString(map[string]string{
+			"host":     conn.Host,
+			"dbs_port": port,
+			"user":     conn.User,
+			"password": conn.Password,
+		})
+
+	case connectionFormatODBC:
+		return fmt.Sprintf("DRIVER={Teradata Database ODBC Driver 17.20};DBCNAME=%s;TDMSTPORTNUMBER=%s;UID=%s;PWD=%s;",
+			conn.Host, port, odbcValue(conn.User), odbcValue(conn.Password)), nil
+
+	case connectionFormatSQLAlchemy:
+		u := url.URL{
+			Scheme:   "teradatasql",
+			User:     url.UserPassword(conn.User, conn.Password),
+			Host:     conn.Host,
+			Path:     "/",
+			RawQuery: url.Values{"dbs_port": []string{port}}.Encode(),
+		}
+		return u.String(), nil
+
+	case connectionFormatTeradataML:
+		return jsonString(map[string]string{
+			"host":     conn.Host,
+			"dbs_port": port,
+			"username": conn.User,
+			"password": conn.Password,
+		})
+	}
+
+	return "", fmt.Errorf("unsupported format %q, expected one of: %s", format, strings.Join(connectionFormats, ", "))
+}
+
+func jsonString(v map[string]string) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// jdbcValue quotes values containing characters with a meaning in Teradata
+// JDBC URL parameters, doubling embedded single quotes.
+func jdbcValue(v string) string {
+	if strings.ContainsAny(v, ",/'") {
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return v
+}
+
+// odbcValue braces values containing characters with a meaning in ODBC
+// connection strings.
+func odbcValue(v string) string {
+	if strings.ContainsAny(v, ";{}=") {
+		return "{" + strings.ReplaceAll(v, "}", "}}") + "}"
+	}
+	return v
+}
+
+// environmentFromValue converts an environment object from configuration,
+// as exposed by the environment resource and data source, to the API model.
+func environmentFromValue(v attr.Value) (*client.Environment, error) {
+	obj, ok := v.(basetypes.ObjectValue)
+	if !ok {
+		return nil, fmt.Errorf("expected an environment object, got %s", typeName(v))
+	}
+	attrs := obj.Attributes()
+
+	env := &client.Environment{
+		Name:    stringAttr(attrs, "name"),
+		DNSName: stringAttr(attrs, "dnsname"),
+		IP:      stringAttr(attrs, "ip"),
+	}
+
+	services, ok := attrs["services"]
+	if !ok {
+		return nil, fmt.Errorf("environment object has no services attribute")
+	}
+	for _, s := range elements(services) {
+		service, ok := s.(basetypes.ObjectValue)
+		if !ok {
+			continue
+		}
+		serviceAttrs := service.Attributes()
+
+		svc := client.Service{
+			Name: stringAttr(serviceAttrs, "name"),
+			URL:  stringAttr(serviceAttrs, "url"),
+		}
+		for _, c := range elements(serviceAttrs["credentials"]) {
+			cred, ok := c.(basetypes.ObjectValue)
+			if !ok {
+				continue
+			}
+			credAttrs := cred.Attributes()
+			svc.Credentials = append(svc.Credentials, client.Credential{
+				Name:  stringAttr(credAttrs, "name"),
+				Value: stringAttr(credAttrs, "value"),
+			})
+		}
+		env.Services = append(env.Services, svc)
+	}
+
+	if env.DNSName == "" && env.IP == "" && env.Service("vantage") == nil {
+		return nil, fmt.Errorf("environment object has neither a dnsname nor a vantage service")
+	}
+
+	return env, nil
+}
+
+// elements returns the elements of a list, set, tuple or map value. Map
+// elements are returned in key order.
+func elements(v attr.Value) []attr.Value {
+	switch t := v.(type) {
+	case basetypes.ListValue:
+		return t.Elements()
+	case basetypes.SetValue:
+		return t.Elements()
+	case basetypes.TupleValue:
+		return t.Elements()
+	case basetypes.MapValue:
+		m := t.Elements()
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]attr.Value, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, m[k])
+		}
+		return out
+	case basetypes.DynamicValue:
+		return elements(t.UnderlyingValue())
+	}
+	return nil
+}
+
+func stringAttr(attrs map[string]attr.Value, name string) string {
+	switch v := attrs[name].(type) {
+	case basetypes.StringValue:
+		return v.ValueString()
+	case basetypes.DynamicValue:
+		if s, ok := v.UnderlyingValue().(basetypes.StringValue); ok {
+			return s.ValueString()
+		}
+	}
+	return ""
+}
+
+func typeName(v attr.Value) string {
+	if v == nil {
+		return "null"
+	}
+	return v.Type(context.Background()).String()
+}
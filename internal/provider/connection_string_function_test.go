@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-teradata-clearscape/internal/client"
+)
+
+func TestConnectionStringEscapesCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		user     string
+		password string
+		want     string
+	}{
+		{
+			name:     "jdbc plain",
+			format:   connectionFormatJDBC,
+			user:     "demo_user",
+			password: "s3cret",
+			want:     "jdbc:teradata://env1.example/DBS_PORT=1025,USER=demo_user,PASSWORD=s3cret",
+		},
+		{
+			name:     "jdbc quoted",
+			format:   connectionFormatJDBC,
+			user:     "demo_user",
+			password: "a,b/c'd",
+			want:     "jdbc:teradata://env1.example/DBS_PORT=1025,USER=demo_user,PASSWORD='a,b/c''d'",
+		},
+		{
+			name:     "odbc braced",
+			format:   connectionFormatODBC,
+			user:     "demo_user",
+			password: "a;b}c",
+			want:     "DRIVER={Teradata Database ODBC Driver 17.20};DBCNAME=env1.example;TDMSTPORTNUMBER=1025;UID=demo_user;PWD={a;b}}c};",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connectionString(client.Connection{Host: "env1.example", Port: 1025, User: tt.user, Password: tt.password}, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-teradata-clearscape/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// actionRestart is the restart action, a stop followed by a start.
+const actionRestart = "restart"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &environmentOperationAction{}
+	_ action.ActionWithConfigure      = &environmentOperationAction{}
+	_ action.ActionWithValidateConfig = &environmentOperationAction{}
+	_ action.ActionWithModifyPlan     = &environmentOperationAction{}
+)
+
+func EnvironmentStartAction() action.Action {
+	return &environmentOperationAction{operation: client.OperationStart}
+}
+
+func EnvironmentStopAction() action.Action {
+	return &environmentOperationAction{operation: client.OperationStop}
+}
+
+func EnvironmentRestartAction() action.Action {
+	return &environmentOperationAction{operation: actionRestart}
+}
+
+// environmentOperationAction powers an environment on or off and waits until
+// it reaches the matching state.
+type environmentOperationAction struct {
+	client    *client.Client
+	readOnly  bool
+	operation string
+}
+
+type environmentOperationActionModel struct {
+	Name    types.String `tfsdk:"name"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// Metadata returns the action type name.
+func (a *environmentOperationAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_" + a.operation
+}
+
+// Schema defines the schema for the action.
+func (a *environmentOperationAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	description := fmt.Sprintf("%ss an environment and waits until it is %s.", a.verb(), client.StateRunning)
+	if a.operation == client.OperationStop {
+		description = fmt.Sprintf("Stops an environment and waits until it is %s.", client.StateStopped)
+	}
+
+	resp.Schema = schema.Schema{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the environment.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How long to wait for the environment, as a positive Go duration such as `30m`. Defaults to `%s`.", defaultUpdateTimeout),
+			},
+		},
+	}
+}
+
+// verb returns the capitalised operation for messages.
+func (a *environmentOperationAction) verb() string {
+	switch a.operation {
+	case client.OperationStart:
+		return "Start"
+	case client.OperationStop:
+		return "Stop"
+	}
+	return "Restart"
+}
+
+// Configure adds the provider configured client to the action.
+func (a *environmentOperationAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = data.client
+	a.readOnly = data.readOnly
+}
+
+// ValidateConfig ensures the timeout is a valid, positive duration.
+func (a *environmentOperationAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config environmentOperationActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Timeout.IsNull() || config.Timeout.IsUnknown() {
+		return
+	}
+	if _, err := parseActionTimeout(config.Timeout.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid Timeout",
+			fmt.Sprintf("`timeout` must be a positive duration such as `30m`: %s", err),
+		)
+	}
+}
+
+// parseActionTimeout parses the timeout of an action, which must be
+// positive.
+func parseActionTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return timeout, nil
+}
+
+// ModifyPlan fails the plan when the provider is read-only.
+func (a *environmentOperationAction) ModifyPlan(_ context.Context, _ action.ModifyPlanRequest, resp *action.ModifyPlanResponse) {
+	if a.readOnly {
+		resp.Diagnostics.Append(readOnlyError(a.operation + " environments")...)
+	}
+}
+
+// Invoke applies the operation to the environment.
+func (a *environmentOperationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config environmentOperationActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultUpdateTimeout
+	if !config.Timeout.IsNull() {
+		var err error
+		timeout, err = parseActionTimeout(config.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid Timeout",
+				fmt.Sprintf("`timeout` must be a positive duration such as `30m`: %s", err),
+			)
+			return
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	name := config.Name.ValueString()
+	tflog.Info(ctx, "Invoking ClearScape Environment Action", map[string]interface{}{"name": name, "operation": a.operation})
+
+	operations := []string{a.operation}
+	if a.operation == actionRestart {
+		operations = []string{client.OperationStop, client.OperationStart}
+	}
+
+	for _, operation := range operations {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Waiting for %s ClearScape Environment to %s", name, operation),
+		})
+
+		env, err := applyOperation(ctx, a.client, name, operation)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to %s %s ClearScape Environment", a.verb(), name), err.Error())
+			return
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("%s ClearScape Environment is %s", name, env.State),
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testActionConfig(t *testing.T, a *environmentOperationAction, timeout string) tfsdk.Config {
+	t.Helper()
+
+	var schemaResp action.SchemaResponse
+	a.Schema(context.Background(), action.SchemaRequest{}, &schemaResp)
+
+	var timeoutValue tftypes.Value
+	if timeout == "" {
+		timeoutValue = tftypes.NewValue(tftypes.String, nil)
+	} else {
+		timeoutValue = tftypes.NewValue(tftypes.String, timeout)
+	}
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "env1"),
+			"timeout": timeoutValue,
+		}),
+	}
+}
+
+func TestEnvironmentOperationActionTimeout(t *testing.T) {
+	tests := []struct {
+		timeout string
+		valid   bool
+	}{
+		{timeout: "", valid: true},
+		{timeout: "30m", valid: true},
+		{timeout: "0s", valid: false},
+		{timeout: "-5m", valid: false},
+		{timeout: "soon", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeout, func(t *testing.T) {
+			a := &environmentOperationAction{operation: actionRestart}
+			config := testActionConfig(t, a, tt.timeout)
+
+			var validateResp action.ValidateConfigResponse
+			a.ValidateConfig(context.Background(), action.ValidateConfigRequest{Config: config}, &validateResp)
+			if got := !validateResp.Diagnostics.HasError(); got != tt.valid {
+				t.Errorf("ValidateConfig valid = %t, want %t: %v", got, tt.valid, validateResp.Diagnostics)
+			}
+
+			if tt.valid {
+				return
+			}
+			// Invoke must fail before calling the API, so no client is needed.
+			var invokeResp action.InvokeResponse
+			a.Invoke(context.Background(), action.InvokeRequest{Config: config}, &invokeResp)
+			if !invokeResp.Diagnostics.HasError() {
+				t.Error("Invoke accepted an invalid timeout")
+			}
+		})
+	}
+}
+
+func TestEnvironmentOperationActionReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{true, false} {
+		a := &environmentOperationAction{operation: client.OperationStop, readOnly: readOnly}
+
+		var resp action.ModifyPlanResponse
+		a.ModifyPlan(context.Background(), action.ModifyPlanRequest{Config: testActionConfig(t, a, "")}, &resp)
+		if resp.Diagnostics.HasError() != readOnly {
+			t.Errorf("read_only = %t: diagnostics = %v", readOnly, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &environmentCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &environmentCredentialsEphemeralResource{}
+)
+
+func EnvironmentCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &environmentCredentialsEphemeralResource{}
+}
+
+// environmentCredentialsEphemeralResource fetches the current credentials of
+// an environment without persisting them in plan or state.
+type environmentCredentialsEphemeralResource struct {
+	client *client.Client
+}
+
+type environmentCredentialsEphemeralResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	Host         types.String `tfsdk:"host"`
+	Port         types.Int64  `tfsdk:"port"`
+	User         types.String `tfsdk:"user"`
+	Password     types.String `tfsdk:"password"`
+	JupyterURL   types.String `tfsdk:"jupyter_url"`
+	JupyterToken types.String `tfsdk:"jupyter_token"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *environmentCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_credentials"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *environmentCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the current Vantage and Jupyter credentials of an environment without storing them in plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the environment.",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The host name of the Vantage SQL engine.",
+			},
+			"port": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The port of the Vantage SQL engine.",
+			},
+			"user": schema.StringAttribute{
+				Computed:    true,
+				Description: "The database user name.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The database password.",
+			},
+			"jupyter_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the Jupyter service.",
+			},
+			"jupyter_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token of the Jupyter service.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *environmentCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = data.client
+}
+
+// Open fetches the credentials of the environment.
+func (e *environmentCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data environmentCredentialsEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Fetching ClearScape Environment Credentials", map[string]interface{}{"name": data.Name.ValueString()})
+
+	env, err := e.client.GetEnvironment(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Read %s ClearScape Environment", data.Name.ValueString()), err.Error())
+		return
+	}
+
+	conn := env.Connection()
+	data.Host = types.StringValue(conn.Host)
+	data.Port = types.Int64Value(conn.Port)
+	data.User = types.StringValue(conn.User)
+	data.Password = types.StringValue(conn.Password)
+	data.JupyterURL = types.StringValue(conn.JupyterURL)
+	data.JupyterToken = types.StringValue(conn.JupyterToken)
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
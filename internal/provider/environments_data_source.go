@@ -33,16 +33,15 @@ type environmentDataSourceModel struct {
 }
 
 type environmentModel struct {
-	Name        types.String   `tfsdk:"name"`
-	Region      types.String   `tfsdk:"region"`
-	State       types.String   `tfsdk:"state"`
-	IP          types.String   `tfsdk:"ip"`
-	DNSName     types.String   `tfsdk:"dnsname"`
-	Owner       types.String   `tfsdk:"owner"`
-	Type        types.String   `tfsdk:"type"`
-	Services    []serviceModel `tfsdk:"services"`
-	LastUpdated types.String   `tfsdk:"last_updated"`
-	Operation   types.String   `tfsdk:"operation"`
+	Name           types.String                 `tfsdk:"name"`
+	Region         types.String                 `tfsdk:"region"`
+	State          types.String                 `tfsdk:"state"`
+	IP             types.String                 `tfsdk:"ip"`
+	DNSName        types.String                 `tfsdk:"dnsname"`
+	Owner          types.String                 `tfsdk:"owner"`
+	Type           types.String                 `tfsdk:"type"`
+	Services       []serviceModel               `tfsdk:"services"`
+	ServicesByName map[string]namedServiceModel `tfsdk:"services_by_name"`
 }
 
 type serviceModel struct {
@@ -51,6 +50,12 @@ type serviceModel struct {
 	Credentials []credentialModel `tfsdk:"credentials"`
 }
 
+type namedServiceModel struct {
+	Name        types.String            `tfsdk:"name"`
+	URL         types.String            `tfsdk:"url"`
+	Credentials map[string]types.String `tfsdk:"credentials"`
+}
+
 type credentialModel struct {
 	Name  types.String `tfsdk:"name"`
 	Value types.String `tfsdk:"value"`
@@ -103,7 +108,8 @@ func (d *environmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 													Computed: true,
 												},
 												"value": schema.StringAttribute{
-													Computed: true,
+													Computed:  true,
+													Sensitive: true,
 												},
 											},
 										},
@@ -111,6 +117,24 @@ func (d *environmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 								},
 							},
 						},
+						"services_by_name": schema.MapNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed: true,
+									},
+									"url": schema.StringAttribute{
+										Computed: true,
+									},
+									"credentials": schema.MapAttribute{
+										Computed:    true,
+										Sensitive:   true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -122,7 +146,7 @@ func (d *environmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state environmentDataSourceModel
 
-	environments, err := d.client.GetEnvironments()
+	environments, err := d.client.GetEnvironments(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get environments", err.Error())
 		return
@@ -137,6 +161,8 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 			DNSName: types.StringValue(env.DNSName),
 			Owner:   types.StringValue(env.Owner),
 			Type:    types.StringValue(env.Type),
+
+			ServicesByName: map[string]namedServiceModel{},
 		}
 
 		for _, service := range env.Services {
@@ -144,15 +170,22 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 				Name: types.StringValue(service.Name),
 				URL:  types.StringValue(service.URL),
 			}
+			named := namedServiceModel{
+				Name:        types.StringValue(service.Name),
+				URL:         types.StringValue(service.URL),
+				Credentials: map[string]types.String{},
+			}
 
 			for _, cred := range service.Credentials {
 				s.Credentials = append(s.Credentials, credentialModel{
 					Name:  types.StringValue(cred.Name),
 					Value: types.StringValue(cred.Value),
 				})
+				named.Credentials[cred.Name] = types.StringValue(cred.Value)
 			}
 
 			environment.Services = append(environment.Services, s)
+			environment.ServicesByName[service.Name] = named
 		}
 
 		state.Environments = append(state.Environments, environment)
@@ -175,15 +208,15 @@ func (d *environmentDataSource) Configure(_ context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
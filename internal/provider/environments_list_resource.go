@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &environmentListResource{}
+	_ list.ListResourceWithConfigure = &environmentListResource{}
+)
+
+func EnvironmentListResource() list.ListResource {
+	return &environmentListResource{}
+}
+
+// environmentListResource enumerates the environments of the account for
+// terraform query, so that existing environments can be imported in bulk.
+type environmentListResource struct {
+	client     *client.Client
+	namePrefix string
+}
+
+type environmentListResourceModel struct {
+	Region     types.String `tfsdk:"region"`
+	State      types.String `tfsdk:"state"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// Metadata returns the resource type name listed by the list resource.
+func (l *environmentListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (l *environmentListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the environments of the account. With a provider `name_prefix`, only environments whose name starts with it are listed.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list environments in this region.",
+			},
+			"state": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list environments in this state, e.g. `RUNNING` or `STOPPED`.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list environments whose name starts with this prefix.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the list resource.
+func (l *environmentListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.client = data.client
+	l.namePrefix = data.defaults.namePrefix
+}
+
+// List streams the environments matching the filters.
+func (l *environmentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config environmentListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Info(ctx, "Listing ClearScape Environments", map[string]interface{}{"region": config.Region.ValueString(), "state": config.State.ValueString(), "name_prefix": config.NamePrefix.ValueString()})
+
+	environments, err := l.client.GetEnvironments(ctx)
+	if err != nil {
+		diags.AddError("Unable to List ClearScape Environments", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for i := range *environments {
+			env := &(*environments)[i]
+			if !config.matches(env, l.namePrefix) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			if !push(environmentListResult(ctx, req, env, l.namePrefix)) {
+				return
+			}
+		}
+	}
+}
+
+// matches reports whether the environment passes the configured filters.
+// Environments without the name prefix of the provider are skipped, as
+// they cannot be managed with it.
+func (m environmentListResourceModel) matches(env *client.Environment, namePrefix string) bool {
+	if !strings.HasPrefix(env.Name, namePrefix) {
+		return false
+	}
+	if region := m.Region.ValueString(); region != "" && normalizeRegion(env.Region) != normalizeRegion(region) {
+		return false
+	}
+	if state := m.State.ValueString(); state != "" && !strings.EqualFold(env.State, state) {
+		return false
+	}
+	return strings.HasPrefix(env.Name, m.NamePrefix.ValueString())
+}
+
+// environmentListResult converts an environment to a list result carrying
+// its identity and, when requested, its full resource state. The name
+// prefix of the provider is removed from the name in the state.
+func environmentListResult(ctx context.Context, req list.ListRequest, env *client.Environment, namePrefix string) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = env.Name
+
+	data := newEnvironmentResourceModel()
+	data.Name = types.StringValue(strings.TrimPrefix(env.Name, namePrefix))
+	result.Diagnostics.Append(data.refresh(ctx, env)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, data.identity())...)
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+	}
+	return result
+}
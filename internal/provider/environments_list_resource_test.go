@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvironmentListResourceMatches(t *testing.T) {
+	tests := map[string]struct {
+		env        client.Environment
+		namePrefix string
+		filter     environmentListResourceModel
+		want       bool
+	}{
+		"no filters": {
+			env:  client.Environment{Name: "env1", Region: "us-central", State: client.StateRunning},
+			want: true,
+		},
+		"provider prefix": {
+			env:        client.Environment{Name: "ci-env1", Region: "us-central"},
+			namePrefix: "ci-",
+			want:       true,
+		},
+		"outside provider prefix": {
+			env:        client.Environment{Name: "env1", Region: "us-central"},
+			namePrefix: "ci-",
+		},
+		"filter prefix within provider prefix": {
+			env:        client.Environment{Name: "ci-env1", Region: "us-central"},
+			namePrefix: "ci-",
+			filter:     environmentListResourceModel{NamePrefix: types.StringValue("ci-env")},
+			want:       true,
+		},
+		"filter prefix": {
+			env:    client.Environment{Name: "env1", Region: "us-central"},
+			filter: environmentListResourceModel{NamePrefix: types.StringValue("ci-")},
+		},
+		"region": {
+			env:    client.Environment{Name: "env1", Region: "US Central"},
+			filter: environmentListResourceModel{Region: types.StringValue("us-central")},
+			want:   true,
+		},
+		"other region": {
+			env:    client.Environment{Name: "env1", Region: "europe-west"},
+			filter: environmentListResourceModel{Region: types.StringValue("us-central")},
+		},
+		"state": {
+			env:    client.Environment{Name: "env1", State: client.StateStopped},
+			filter: environmentListResourceModel{State: types.StringValue("stopped")},
+			want:   true,
+		},
+		"other state": {
+			env:    client.Environment{Name: "env1", State: client.StateRunning},
+			filter: environmentListResourceModel{State: types.StringValue(client.StateStopped)},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.filter.matches(&tt.env, tt.namePrefix); got != tt.want {
+				t.Errorf("matches = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,19 +3,49 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"terraform-provider-teradata-clearscape/internal/client"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Default durations of the operations of the environment resource, used
+// unless overridden in the timeouts block.
+const (
+	defaultCreateTimeout = 30 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// Values of the on_destroy attribute.
+const (
+	onDestroyDelete  = "delete"
+	onDestroyStop    = "stop"
+	onDestroyAbandon = "abandon"
+)
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &environmentResource{}
-	_ resource.ResourceWithConfigure = &environmentResource{}
+	_ resource.Resource                   = &environmentResource{}
+	_ resource.ResourceWithConfigure      = &environmentResource{}
+	_ resource.ResourceWithValidateConfig = &environmentResource{}
+	_ resource.ResourceWithUpgradeState   = &environmentResource{}
+	_ resource.ResourceWithIdentity       = &environmentResource{}
+	_ resource.ResourceWithImportState    = &environmentResource{}
 )
 
 func EnvironmentResource() resource.Resource {
@@ -24,7 +54,10 @@ func EnvironmentResource() resource.Resource {
 
 // environmentResource implements the resource.Resource interface.
 type environmentResource struct {
-	client *client.Client
+	client   *client.Client
+	policy   environmentPolicy
+	defaults environmentDefaults
+	readOnly bool
 }
 
 // Metadata returns the resource type name.
@@ -43,44 +76,172 @@ type environmentServiceModel struct {
 	Credentials types.List   `tfsdk:"credentials"`
 }
 
-type environmentResourceModel struct {
+// environmentNamedServiceModel is a service keyed by name, with its
+// credentials keyed by credential name.
+type environmentNamedServiceModel struct {
 	Name        types.String `tfsdk:"name"`
-	Region      types.String `tfsdk:"region"`
-	State       types.String `tfsdk:"state"`
-	IP          types.String `tfsdk:"ip"`
-	DNSName     types.String `tfsdk:"dnsname"`
-	Owner       types.String `tfsdk:"owner"`
-	Type        types.String `tfsdk:"type"`
-	LastUpdated types.String `tfsdk:"last_updated"`
-	Operation   types.String `tfsdk:"operation"`
-	Password    types.String `tfsdk:"password"`
-	Services    types.List   `tfsdk:"services"`
+	URL         types.String `tfsdk:"url"`
+	Credentials types.Map    `tfsdk:"credentials"`
+}
+
+type environmentResourceModel struct {
+	Name               types.String   `tfsdk:"name"`
+	FullName           types.String   `tfsdk:"full_name"`
+	Region             RegionValue    `tfsdk:"region"`
+	State              types.String   `tfsdk:"state"`
+	IP                 types.String   `tfsdk:"ip"`
+	DNSName            types.String   `tfsdk:"dnsname"`
+	Owner              types.String   `tfsdk:"owner"`
+	Type               types.String   `tfsdk:"type"`
+	LastUpdated        TimestampValue `tfsdk:"last_updated"`
+	CreatedAt          TimestampValue `tfsdk:"created_at"`
+	Operation          types.String   `tfsdk:"operation"`
+	Password           types.String   `tfsdk:"password"`
+	PasswordWO         types.String   `tfsdk:"password_wo"`
+	PasswordWOVersion  types.Int64    `tfsdk:"password_wo_version"`
+	Services           types.List     `tfsdk:"services"`
+	ServicesByName     types.Map      `tfsdk:"services_by_name"`
+	ConnectionInfo     types.Object   `tfsdk:"connection_info"`
+	OnDestroy          types.String   `tfsdk:"on_destroy"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	Tags               types.Map      `tfsdk:"tags"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// newEnvironmentResourceModel returns a model with the arguments of an
+// environment not managed by Terraform yet set to their defaults, to be
+// filled in by refresh.
+func newEnvironmentResourceModel() environmentResourceModel {
+	return environmentResourceModel{
+		LastUpdated:        NewTimestampNull(),
+		CreatedAt:          NewTimestampNull(),
+		Operation:          types.StringNull(),
+		Password:           types.StringNull(),
+		PasswordWO:         types.StringNull(),
+		PasswordWOVersion:  types.Int64Null(),
+		OnDestroy:          types.StringValue(onDestroyDelete),
+		DeletionProtection: types.BoolValue(false),
+		AdoptExisting:      types.BoolValue(true),
+		Tags:               types.MapNull(types.StringType),
+		Timeouts:           nullTimeouts(),
+	}
+}
+
+// environmentIdentityModel identifies an environment across workspaces.
+// Names are only unique within an account, so the owner is part of it.
+type environmentIdentityModel struct {
+	Name  types.String `tfsdk:"name"`
+	Owner types.String `tfsdk:"owner"`
+}
+
+// environmentConnectionModel holds the settings needed to connect to the
+// Vantage SQL engine and Jupyter service of an environment.
+type environmentConnectionModel struct {
+	Host         types.String `tfsdk:"host"`
+	Port         types.Int64  `tfsdk:"port"`
+	User         types.String `tfsdk:"user"`
+	Password     types.String `tfsdk:"password"`
+	JupyterURL   types.String `tfsdk:"jupyter_url"`
+	JupyterToken types.String `tfsdk:"jupyter_token"`
 }
 
 // Schema defines the schema for the resource.
-func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *environmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "The name of the environment.",
+				Description: "The name of the environment, without the `name_prefix` of the provider. Changing it replaces the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"full_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the environment in ClearScape, `name` prefixed with the `name_prefix` of the provider. Changing the prefix replaces the environment.",
 			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
-				Description: "The last time the environment was updated.",
+				CustomType:  TimestampType{},
+				Description: "The time the environment was last updated, as an RFC 3339 timestamp.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				CustomType:  TimestampType{},
+				Description: "The time the environment was created, as an RFC 3339 timestamp.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"operation": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The last operation performed on the environment.",
+				Description: "The power operation last applied to the environment, `start` or `stop`. Changing it starts or stops the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"region": schema.StringAttribute{
-				Required:    true,
-				Description: "The region of the environment.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  RegionType{},
+				Description: "The region of the environment. Defaults to the `default_region` of the provider when creating the environment. Case and separators are ignored, so `us-central` and `US Central` are the same region. Changing it to another region replaces the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						regionChanged,
+						"Changing the region replaces the environment.",
+						"Changing the region replaces the environment.",
+					),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags assigned to the environment. Changing them updates the environment in place.",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The password for the environment.",
+				Description: "The password for the environment. Changing it rotates the database password in place. It is stored in state; use `password_wo` to avoid that. Exactly one of `password` or `password_wo` must be set.",
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The password for the environment, never stored in plan or state. Requires Terraform 1.11 or later. Exactly one of `password` or `password_wo` must be set.",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(onDestroyDelete),
+				Description: "What happens to the environment when the resource is destroyed: `delete` deletes it, `stop` stops it and `abandon` leaves it running. With `stop` and `abandon` the environment is only removed from state. Defaults to `delete`.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether Terraform is prevented from destroying the environment. Set it to `false` and apply before destroying. Defaults to `false`.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether an environment with the same name and region owned by the caller is adopted into state when creating it fails with an ambiguous error, such as a timeout or a server error, instead of being left as an orphan. Defaults to `true`.",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `password_wo`. As `password_wo` is not stored, change this value to rotate the database password in place to the current `password_wo`.",
 			},
 			"state": schema.StringAttribute{
 				Computed:    true,
@@ -89,18 +250,30 @@ func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"ip": schema.StringAttribute{
 				Computed:    true,
 				Description: "The IP address of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"dnsname": schema.StringAttribute{
 				Computed:    true,
 				Description: "The DNS name of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"owner": schema.StringAttribute{
 				Computed:    true,
 				Description: "The owner of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Computed:    true,
 				Description: "The type of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"services": schema.ListNestedAttribute{
 				Computed: true,
@@ -124,6 +297,7 @@ func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 									},
 									"value": schema.StringAttribute{
 										Computed:    true,
+										Sensitive:   true,
 										Description: "The value of the credential.",
 									},
 								},
@@ -132,11 +306,127 @@ func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 					},
 				},
 			},
+			"services_by_name": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The services of the environment keyed by service name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the service.",
+						},
+						"url": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL of the service.",
+						},
+						"credentials": schema.MapAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							ElementType: types.StringType,
+							Description: "The credentials of the service keyed by credential name.",
+						},
+					},
+				},
+			},
+			// Terraform reserves the connection attribute name of resources
+			// for provisioners, hence connection_info.
+			"connection_info": schema.SingleNestedAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The settings needed to connect to the Vantage SQL engine and Jupyter service of the environment. It is named `connection_info` because Terraform reserves `connection` for the provisioner connection block of resources.",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Computed:    true,
+						Description: "The host name of the Vantage SQL engine.",
+					},
+					"port": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The port of the Vantage SQL engine.",
+					},
+					"user": schema.StringAttribute{
+						Computed:    true,
+						Description: "The database user name.",
+					},
+					"password": schema.StringAttribute{
+						Computed:    true,
+						Description: "The database password.",
+					},
+					"jupyter_url": schema.StringAttribute{
+						Computed:    true,
+						Description: "The URL of the Jupyter service.",
+					},
+					"jupyter_token": schema.StringAttribute{
+						Computed:    true,
+						Description: "The access token of the Jupyter service.",
+					},
+				},
+			},
 		},
 	}
 }
 
-// Configure adds the provider configured client to the resource.
+// IdentitySchema defines the identity of the resource.
+func (r *environmentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The name of the environment.",
+			},
+			"owner": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The owner of the environment.",
+			},
+		},
+	}
+}
+
+// identity returns the identity of the environment in the model.
+func (m environmentResourceModel) identity() environmentIdentityModel {
+	return environmentIdentityModel{
+		Name:  types.StringValue(m.environmentName()),
+		Owner: m.Owner,
+	}
+}
+
+// environmentName returns the name of the environment in the API. State
+// written before full_name was introduced only has the name.
+func (m environmentResourceModel) environmentName() string {
+	if m.FullName.IsNull() || m.FullName.IsUnknown() {
+		return m.Name.ValueString()
+	}
+	return m.FullName.ValueString()
+}
+
+// ImportState imports an environment by its full name, given either as the
+// import ID or in the identity. An owner in the identity is checked by Read.
+func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("full_name"), path.Root("name"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fullName types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("full_name"), &fullName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	name, diags := trimNamePrefix(fullName.ValueString(), r.defaults.namePrefix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+
+	// Set the defaults of the arguments so that a configuration relying on
+	// them has no changes after the import.
+	defaults := newEnvironmentResourceModel()
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), defaults.OnDestroy)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), defaults.DeletionProtection)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), defaults.AdoptExisting)...)
+}
+
+// Configure adds the provider configured client and policy to the resource.
 func (r *environmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
 	// sets that data after it calls the ConfigureProvider RPC.
@@ -144,99 +434,272 @@ func (r *environmentResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.policy = data.policy
+	r.defaults = data.defaults
+	r.readOnly = data.readOnly
+}
+
+// ValidateConfig ensures exactly one of the password arguments is set.
+func (r *environmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config environmentResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values may still resolve to null, so only check known ones.
+	passwordsKnown := !config.Password.IsUnknown() && !config.PasswordWO.IsUnknown()
+
+	if passwordsKnown && config.Password.IsNull() && config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Environment Password",
+			"One of `password` or `password_wo` must be set.",
+		)
+	}
+
+	if passwordsKnown && !config.Password.IsNull() && !config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting Environment Password",
+			"Only one of `password` or `password_wo` can be set.",
+		)
+	}
+
+	if !config.OnDestroy.IsNull() && !config.OnDestroy.IsUnknown() {
+		switch config.OnDestroy.ValueString() {
+		case onDestroyDelete, onDestroyStop, onDestroyAbandon:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_destroy"),
+				"Invalid On Destroy Behaviour",
+				fmt.Sprintf("`on_destroy` must be one of %q, %q or %q, got %q.", onDestroyDelete, onDestroyStop, onDestroyAbandon, config.OnDestroy.ValueString()),
+			)
+		}
+	}
+
+	if !config.Operation.IsNull() && !config.Operation.IsUnknown() {
+		switch config.Operation.ValueString() {
+		case client.OperationStart, client.OperationStop:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("operation"),
+				"Invalid Environment Operation",
+				fmt.Sprintf("`operation` must be %q or %q, got %q.", client.OperationStart, client.OperationStop, config.Operation.ValueString()),
+			)
+		}
+	}
+
+	if !config.PasswordWOVersion.IsNull() && config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo_version"),
+			"Missing Write-Only Password",
+			"`password_wo_version` can only be set together with `password_wo`.",
+		)
+	}
+}
+
+// configuredPassword returns the password from the plan, or the write-only
+// password from the configuration when the plan has none.
+func configuredPassword(ctx context.Context, config tfsdk.Config, plan environmentResourceModel) (string, diag.Diagnostics) {
+	if !plan.Password.IsNull() {
+		return plan.Password.ValueString(), nil
+	}
+
+	var password types.String
+	diags := config.GetAttribute(ctx, path.Root("password_wo"), &password)
+	return password.ValueString(), diags
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 
-	tflog.Info(ctx, "Creating New ClearScape Environment")
-
 	var plan environmentResourceModel
 
-	tflog.Info(ctx, "Before Mapping")
 	diags := req.Plan.Get(ctx, &plan)
-	tflog.Info(ctx, "After Mapping")
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	tflog.Info(ctx, "After Error", map[string]interface{}{"name": plan.Name.ValueString(), "region": plan.Region.ValueString(), "state": plan.State.ValueString(), "ip": plan.IP.ValueString(), "dnsname": plan.DNSName.ValueString(), "owner": plan.Owner.ValueString(), "type": plan.Type.ValueString(), "password": plan.Password.ValueString()})
+	password, diags := configuredPassword(ctx, req.Config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = client.MaskLogContext(ctx, password)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Creating New ClearScape Environment", map[string]interface{}{"name": plan.environmentName(), "region": plan.Region.ValueString()})
+
 	// Generate API request body from plan
 
 	var envRequest client.EnvironmentCreateRequest
-	envRequest.Name = plan.Name.ValueString()
+	envRequest.Name = plan.environmentName()
 	envRequest.Region = plan.Region.ValueString()
-	envRequest.Password = plan.Password.ValueString()
+	envRequest.Password = password
 
-	tflog.Info(ctx, "Environment Details %s", map[string]interface{}{"name": plan.Name.ValueString(), "region": plan.Region.ValueString(), "password": plan.Password.ValueString()})
+	diags = plan.Tags.ElementsAs(ctx, &envRequest.Tags, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An environment being replaced is destroyed by now, so one with the
+	// same name is not managed by this resource.
+	if _, err := r.client.GetEnvironment(ctx, envRequest.Name); err == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"ClearScape Environment Name Already Taken",
+			fmt.Sprintf("An environment named %q already exists and is not managed by this resource. "+
+				"Choose another name, or import the existing environment with an import block or `terraform import`.", envRequest.Name),
+		)
+		return
+	}
 
-	env, err := r.client.CreateEnvironment(envRequest)
-	tflog.Info(ctx, "Environment Created %s", map[string]interface{}{"name": env.Name, "region": env.Region, "state": env.State, "ip": env.IP, "dnsname": env.DNSName, "owner": env.Owner, "type": env.Type})
+	// The plan may have counted the environment this one replaces, which is
+	// gone by now, so enforce max_environments on the actual count.
+	if r.policy.maxEnvironments > 0 {
+		environments, err := r.client.GetEnvironments(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Check Environment Limit of Provider Policy",
+				fmt.Sprintf("The environments of the account could not be listed to check that %q fits in max_environments: %s", envRequest.Name, err),
+			)
+			return
+		}
+		resp.Diagnostics.Append(r.policy.checkCount(envRequest.Name, len(*environments), false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
+	env, err := r.client.CreateEnvironment(ctx, envRequest)
+	if client.IsAmbiguous(err) && plan.AdoptExisting.ValueBool() {
+		if adopted := r.findCreatedEnvironment(ctx, envRequest); adopted != nil {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Adopted %s ClearScape Environment", envRequest.Name),
+				fmt.Sprintf("Creating the environment failed with an ambiguous error, but an environment with the same name and region owned by the caller exists and was adopted into state: %s", err),
+			)
+			env, err = adopted, nil
+		}
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create environment", err.Error())
 		return
 	}
+	ctx = client.MaskLogContext(ctx, env.Secrets()...)
 
-	for _, service := range env.Services {
-		tflog.Info(ctx, "Service Details %s", map[string]interface{}{"name": service.Name, "url": service.URL})
-		for _, cred := range service.Credentials {
-			tflog.Info(ctx, "Service Credentials %s", map[string]interface{}{"name": cred.Name, "value": cred.Value})
+	if !strings.EqualFold(env.State, client.StateRunning) {
+		tflog.Info(ctx, "Waiting for ClearScape Environment to be running", map[string]interface{}{"name": env.Name, "state": env.State})
+
+		ready, err := r.client.WaitForState(ctx, env.Name, client.StateRunning)
+		if ready != nil {
+			env = ready
 		}
+		if err != nil {
+			saveCreated(ctx, &plan, env, resp)
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("%s ClearScape Environment Did Not Become Ready", env.Name),
+				fmt.Sprintf("The environment was created but is %s instead of %s. It has been saved to state and will be replaced on the next apply: %s", env.State, client.StateRunning, err),
+			)
+			return
+		}
+	}
 
+	if plan.Operation.ValueString() == client.OperationStop {
+		stopped, err := applyOperation(ctx, r.client, env.Name, client.OperationStop)
+		if stopped != nil {
+			env = stopped
+		}
+		if err != nil {
+			saveCreated(ctx, &plan, env, resp)
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Failed to Stop %s ClearScape Environment", envRequest.Name),
+				fmt.Sprintf("The environment was created but could not be stopped. It has been saved to state and will be replaced on the next apply: %s", err),
+			)
+			return
+		}
 	}
 
-	environment := environmentResourceModel{
-		Name:     types.StringValue(env.Name),
-		Region:   types.StringValue(env.Region),
-		State:    types.StringValue(env.State),
-		IP:       types.StringValue(env.IP),
-		DNSName:  types.StringValue(env.DNSName),
-		Owner:    types.StringValue(env.Owner),
-		Type:     types.StringValue(env.Type),
-		Password: types.StringValue(envRequest.Password),
+	tflog.Info(ctx, "Environment Created", map[string]interface{}{"name": env.Name, "region": env.Region, "state": env.State, "ip": env.IP, "dnsname": env.DNSName, "owner": env.Owner, "type": env.Type})
+
+	for _, service := range env.Services {
+		tflog.Debug(ctx, "Service Details", map[string]interface{}{"name": service.Name, "url": service.URL})
 	}
 
-	services, _ := types.ListValueFrom(ctx, plan.Services.ElementType(ctx), env.Services)
-	environment.Services = services
-	tflog.Info(ctx, "Services %s", map[string]interface{}{"services": services})
+	// Set state to fully populated data
+	saveCreated(ctx, &plan, env, resp)
+}
 
-	/* for _, service := range env.Services {
-		var creds []environmentCredentialModel
-		for _, cred := range service.Credentials {
-			creds = append(creds, environmentCredentialModel{
-				Name:  types.StringValue(cred.Name),
-				Value: types.StringValue(cred.Value),
-			})
-		}
+// saveCreated saves a created environment to state, even when it failed to
+// reach the planned state or to refresh, so that Terraform taints the
+// resource and replaces it on the next apply instead of orphaning it.
+func saveCreated(ctx context.Context, plan *environmentResourceModel, env *client.Environment, resp *resource.CreateResponse) {
+	diags := plan.refresh(ctx, env)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.Identity.Set(ctx, plan.identity())
+	resp.Diagnostics.Append(diags...)
+}
 
-		environment.Services = append(environment.Services, environmentServiceModel{
-			Name:        types.StringValue(service.Name),
-			URL:         types.StringValue(service.URL),
-			Credentials: creds,
-		})
-	} */
+// findCreatedEnvironment looks up an environment owned by the caller matching
+// a create request whose outcome is unknown. It returns nil if there is none
+// or if the owner cannot be checked.
+func (r *environmentResource) findCreatedEnvironment(ctx context.Context, envRequest client.EnvironmentCreateRequest) *client.Environment {
+	// The create context may have expired, which is what made the error
+	// ambiguous in the first place.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+	defer cancel()
 
-	plan = environment
+	account, err := r.client.GetAccount(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to look up ClearScape account after ambiguous create error", map[string]interface{}{"name": envRequest.Name, "error": err.Error()})
+		return nil
+	}
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	environments, err := r.client.GetEnvironments(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to look up ClearScape Environment after ambiguous create error", map[string]interface{}{"name": envRequest.Name, "error": err.Error()})
+		return nil
+	}
+
+	for i, env := range *environments {
+		if env.Name != envRequest.Name || normalizeRegion(env.Region) != normalizeRegion(envRequest.Region) {
+			continue
+		}
+		if !strings.EqualFold(env.Owner, account.Email) {
+			tflog.Warn(ctx, "Not adopting ClearScape Environment owned by someone else", map[string]interface{}{"name": env.Name, "owner": env.Owner})
+			return nil
+		}
+
+		// The list may omit service credentials, so prefer the full details.
+		if full, err := r.client.GetEnvironment(ctx, env.Name); err == nil {
+			return full
+		}
+		return &(*environments)[i]
 	}
+	return nil
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -249,33 +712,47 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	tflog.Info(ctx, "Reading %s ClearScape Environment", map[string]interface{}{"name": state.Name.ValueString()})
-	env, err := r.client.GetEnvironment(state.Name.ValueString())
+	ctx = client.MaskLogContext(ctx, state.Password.ValueString())
+
+	tflog.Info(ctx, "Reading ClearScape Environment", map[string]interface{}{"name": state.environmentName()})
+	env, err := r.client.GetEnvironment(ctx, state.environmentName())
+	if client.IsNotFound(err) {
+		// Deleted outside of Terraform, plan to create it again.
+		tflog.Warn(ctx, "ClearScape Environment not found, removing it from state", map[string]interface{}{"name": state.environmentName()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Read %s ClearScape Environment", state.environmentName()), err.Error())
 		return
 	}
 
-	// Overwrite items with refreshed state
+	if req.Identity != nil {
+		var identity environmentIdentityModel
+		diags = req.Identity.Get(ctx, &identity)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	environment := environmentResourceModel{
-		Name:     types.StringValue(env.Name),
-		Region:   types.StringValue(env.Region),
-		State:    types.StringValue(env.State),
-		IP:       types.StringValue(env.IP),
-		DNSName:  types.StringValue(env.DNSName),
-		Owner:    types.StringValue(env.Owner),
-		Type:     types.StringValue(env.Type),
-		Password: types.StringValue(state.Password.String()),
+		// Another account may have an environment with the same name, e.g.
+		// when importing an identity copied from a different workspace.
+		owner := identity.Owner.ValueString()
+		if owner != "" && !strings.EqualFold(owner, env.Owner) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unexpected %s ClearScape Environment Owner", env.Name),
+				fmt.Sprintf("The environment is owned by %q, but its identity expects %q. Check that the provider is configured with the token of the right account.", env.Owner, owner),
+			)
+			return
+		}
 	}
 
-	services, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: environmentServiceModel{}.AttributeTypes()}, env.Services[1])
-	if d.HasError() {
-		resp.Diagnostics.Append(d...)
+	// Overwrite items with refreshed state
+	diags = state.refresh(ctx, env)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	environment.Services = services
-
-	state = environment
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -284,6 +761,8 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	diags = resp.Identity.Set(ctx, state.identity())
+	resp.Diagnostics.Append(diags...)
 }
 
 func (m environmentCredentialModel) AttributeTypes() map[string]attr.Type {
@@ -295,41 +774,240 @@ func (m environmentCredentialModel) AttributeTypes() map[string]attr.Type {
 
 func (m environmentServiceModel) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"name": types.StringType,
-		"url":  types.StringType,
+		"name":        types.StringType,
+		"url":         types.StringType,
+		"credentials": types.ListType{ElemType: types.ObjectType{AttrTypes: environmentCredentialModel{}.AttributeTypes()}},
+	}
+}
+
+func (m environmentConnectionModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"host":          types.StringType,
+		"port":          types.Int64Type,
+		"user":          types.StringType,
+		"password":      types.StringType,
+		"jupyter_url":   types.StringType,
+		"jupyter_token": types.StringType,
+	}
+}
+
+func (m environmentNamedServiceModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":        types.StringType,
+		"url":         types.StringType,
+		"credentials": types.MapType{ElemType: types.StringType},
+	}
+}
+
+// refresh overwrites the computed attributes of the model with the
+// environment returned by the API. User supplied arguments are kept, the
+// name is only set when unknown, e.g. for environments not managed yet.
+func (m *environmentResourceModel) refresh(ctx context.Context, env *client.Environment) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.FullName = types.StringValue(env.Name)
+	if m.Name.IsNull() || m.Name.IsUnknown() {
+		m.Name = types.StringValue(env.Name)
+	}
+	m.Region = NewRegionValue(env.Region)
+	m.State = types.StringValue(env.State)
+	m.IP = types.StringValue(env.IP)
+	m.DNSName = types.StringValue(env.DNSName)
+	m.Owner = types.StringValue(env.Owner)
+	m.Type = types.StringValue(env.Type)
+
+	m.LastUpdated = timestampFromAPI(ctx, env.UpdatedAt)
+	m.CreatedAt = timestampFromAPI(ctx, env.CreatedAt)
+	if m.Operation.IsUnknown() {
+		m.Operation = types.StringNull()
+	}
+
+	// Keep tags unset unless the environment has some, so an API returning
+	// an empty map does not conflict with a configuration without tags.
+	if len(env.Tags) > 0 || (!m.Tags.IsNull() && !m.Tags.IsUnknown()) {
+		tags, d := types.MapValueFrom(ctx, types.StringType, env.Tags)
+		diags.Append(d...)
+		if env.Tags == nil && !m.Tags.IsNull() {
+			// The API did not report tags, keep the configured ones.
+			tags = m.Tags
+		}
+		m.Tags = tags
+	} else {
+		m.Tags = types.MapNull(types.StringType)
+	}
+
+	services := make([]environmentServiceModel, 0, len(env.Services))
+	servicesByName := make(map[string]environmentNamedServiceModel, len(env.Services))
+	for _, service := range env.Services {
+		creds := make([]environmentCredentialModel, 0, len(service.Credentials))
+		credsByName := make(map[string]string, len(service.Credentials))
+		for _, cred := range service.Credentials {
+			creds = append(creds, environmentCredentialModel{
+				Name:  types.StringValue(cred.Name),
+				Value: types.StringValue(cred.Value),
+			})
+			credsByName[cred.Name] = cred.Value
+		}
+
+		credList, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: environmentCredentialModel{}.AttributeTypes()}, creds)
+		diags.Append(d...)
+		credMap, d := types.MapValueFrom(ctx, types.StringType, credsByName)
+		diags.Append(d...)
+
+		services = append(services, environmentServiceModel{
+			Name:        types.StringValue(service.Name),
+			URL:         types.StringValue(service.URL),
+			Credentials: credList,
+		})
+		servicesByName[service.Name] = environmentNamedServiceModel{
+			Name:        types.StringValue(service.Name),
+			URL:         types.StringValue(service.URL),
+			Credentials: credMap,
+		}
 	}
+	if diags.HasError() {
+		return diags
+	}
+
+	serviceList, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: environmentServiceModel{}.AttributeTypes()}, services)
+	diags.Append(d...)
+	serviceMap, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: environmentNamedServiceModel{}.AttributeTypes()}, servicesByName)
+	diags.Append(d...)
+
+	conn := env.Connection()
+	connection, d := types.ObjectValueFrom(ctx, environmentConnectionModel{}.AttributeTypes(), environmentConnectionModel{
+		Host:         types.StringValue(conn.Host),
+		Port:         types.Int64Value(conn.Port),
+		User:         types.StringValue(conn.User),
+		Password:     types.StringValue(conn.Password),
+		JupyterURL:   types.StringValue(conn.JupyterURL),
+		JupyterToken: types.StringValue(conn.JupyterToken),
+	})
+	diags.Append(d...)
+
+	m.Services = serviceList
+	m.ServicesByName = serviceMap
+	m.ConnectionInfo = connection
+
+	return diags
+}
+
+// timestampFromAPI converts a timestamp reported by the API, which is null
+// when the API did not report it in RFC 3339 format.
+func timestampFromAPI(ctx context.Context, value string) TimestampValue {
+	if value == "" {
+		return NewTimestampNull()
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		tflog.Warn(ctx, "Ignoring invalid ClearScape Environment timestamp", map[string]interface{}{"value": value, "error": err.Error()})
+		return NewTimestampNull()
+	}
+	return NewTimestampValue(t)
+}
+
+// regionChanged requires replacing the environment only if the region
+// changes to another region, not just its spelling.
+func regionChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = normalizeRegion(req.StateValue.ValueString()) != normalizeRegion(req.PlanValue.ValueString())
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan environmentResourceModel
-	diags := req.State.Get(ctx, &plan)
+	var plan, state environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, diags := configuredPassword(ctx, req.Config, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	env, err := r.client.UpdateEnvironment(plan.Name.String(), plan.Operation.String())
+	ctx = client.MaskLogContext(ctx, password, state.Password.ValueString())
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	name := state.environmentName()
+
+	var tags map[string]string
+	diags = plan.Tags.ElementsAs(ctx, &tags, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	operation := plan.Operation.ValueString()
+	operationChanged := !plan.Operation.IsNull() && !plan.Operation.Equal(state.Operation)
+
+	// Start first and stop last, so that a password rotation requested in
+	// the same apply runs against a running environment.
+	if operationChanged && operation == client.OperationStart {
+		if _, err := applyOperation(ctx, r.client, name, client.OperationStart); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to Start %s ClearScape Environment", name), err.Error())
+			return
+		}
+	}
+
+	if passwordChanged(plan, state) {
+		tflog.Info(ctx, "Rotating ClearScape Environment Password", map[string]interface{}{"name": name})
+
+		// The rotation leaves the environment in the state it was in, so a
+		// stopped environment that is not being started stays stopped.
+		settled := client.StateRunning
+		if state.State.ValueString() == client.StateStopped && !(operationChanged && operation == client.OperationStart) {
+			settled = client.StateStopped
+		}
+
+		_, err := r.client.ChangePassword(ctx, name, password)
+		if err == nil {
+			_, err = r.client.WaitForState(ctx, name, settled)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to Rotate %s ClearScape Environment Password", name), err.Error())
+			return
+		}
+	}
+
+	if !plan.Tags.Equal(state.Tags) {
+		tflog.Info(ctx, "Updating ClearScape Environment Tags", map[string]interface{}{"name": name})
+
+		if _, err := r.client.UpdateTags(ctx, name, tags); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to Update %s ClearScape Environment Tags", name), err.Error())
+			return
+		}
+	}
+
+	if operationChanged && operation == client.OperationStop {
+		if _, err := applyOperation(ctx, r.client, name, client.OperationStop); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to Stop %s ClearScape Environment", name), err.Error())
+			return
+		}
+	}
+
+	env, err := r.client.GetEnvironment(ctx, name)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update environment", err.Error())
 		return
 	}
 
 	// Overwrite items with refreshed state
-	environment := environmentResourceModel{
-		Name:     types.StringValue(env.Name),
-		Region:   types.StringValue(env.Region),
-		State:    types.StringValue(env.State),
-		IP:       types.StringValue(env.IP),
-		DNSName:  types.StringValue(env.DNSName),
-		Owner:    types.StringValue(env.Owner),
-		Type:     types.StringValue(env.Type),
-		Password: types.StringValue(plan.Password.String()),
-	}
-
-	services, _ := types.ListValueFrom(ctx, plan.Services.ElementType(ctx), env.Services)
-	environment.Services = services
-	tflog.Info(ctx, "Services %s", map[string]interface{}{"services": services})
-	plan = environment
+	diags = plan.refresh(ctx, env)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &plan)
@@ -337,9 +1015,45 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = resp.Identity.Set(ctx, plan.identity())
+	resp.Diagnostics.Append(diags...)
+}
+
+// applyOperation starts or stops the environment and waits until it reaches
+// the matching state. Environments already in that state are left alone.
+func applyOperation(ctx context.Context, c *client.Client, name string, operation string) (*client.Environment, error) {
+	target := client.StateRunning
+	if operation == client.OperationStop {
+		target = client.StateStopped
+	}
+
+	env, err := c.GetEnvironment(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(env.State, target) {
+		return env, nil
+	}
+
+	tflog.Info(ctx, "Applying ClearScape Environment Operation", map[string]interface{}{"name": name, "operation": operation})
+
+	_, err = c.UpdateEnvironment(ctx, name, operation)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForState(ctx, name, target)
+}
+
+// passwordChanged reports whether the plan sets a new database password,
+// either directly or by bumping the write-only password version.
+func passwordChanged(plan, state environmentResourceModel) bool {
+	return !plan.Password.Equal(state.Password) || !plan.PasswordWOVersion.Equal(state.PasswordWOVersion)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
+// It waits until the environment is gone so that an environment with the
+// same name can be created right after.
 func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state environmentResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -348,10 +1062,80 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.DeleteEnvironment(state.Name.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	name := state.environmentName()
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Cannot Destroy Protected %s ClearScape Environment", name),
+			"The environment has deletion_protection enabled. Set deletion_protection to false and apply the change before destroying it.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.policy.checkDelete(state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch state.OnDestroy.ValueString() {
+	case onDestroyAbandon:
+		tflog.Info(ctx, "Abandoning ClearScape Environment", map[string]interface{}{"name": name})
+		return
+	case onDestroyStop:
+		r.stopOnDestroy(ctx, name, resp)
+		return
+	}
+
+	err := r.client.DeleteEnvironment(ctx, name)
+	switch {
+	case client.IsNotFound(err):
+		tflog.Info(ctx, "ClearScape Environment Already Deleted", map[string]interface{}{"name": name})
+		return
+	case client.IsAlreadyDeleting(err):
+		tflog.Info(ctx, "ClearScape Environment Already Being Deleted", map[string]interface{}{"name": name})
+	case err != nil:
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Delete %s ClearScape Environment", name), err.Error())
+		return
+	}
+
+	err = r.client.WaitForDeletion(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Delete %s ClearScape Environment", name), err.Error())
+		return
+	}
+	tflog.Info(ctx, "Deleted ClearScape Environment", map[string]interface{}{"name": name})
+}
+
+// stopOnDestroy stops the environment instead of deleting it, leaving it to
+// be removed from state.
+func (r *environmentResource) stopOnDestroy(ctx context.Context, name string, resp *resource.DeleteResponse) {
+	env, err := r.client.GetEnvironment(ctx, name)
+	if client.IsNotFound(err) {
+		tflog.Info(ctx, "ClearScape Environment Already Deleted", map[string]interface{}{"name": name})
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Delete %s ClearScape Environment", state.Name.ValueString()), err.Error())
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to Stop %s ClearScape Environment", name), err.Error())
 		return
 	}
-	tflog.Info(ctx, "Deleted %s ClearScape Environment", map[string]interface{}{"name": state.Name.ValueString()})
+
+	if !strings.EqualFold(env.State, client.StateStopped) {
+		_, err = r.client.UpdateEnvironment(ctx, name, client.OperationStop)
+		if err == nil {
+			_, err = r.client.WaitForState(ctx, name, client.StateStopped)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to Stop %s ClearScape Environment", name), err.Error())
+			return
+		}
+	}
+	tflog.Info(ctx, "Stopped ClearScape Environment", map[string]interface{}{"name": name})
 }
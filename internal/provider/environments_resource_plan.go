@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.ResourceWithModifyPlan = &environmentResource{}
+
+// ModifyPlan enforces the read-only mode and policy of the provider
+// configuration and checks at plan time that an environment about to be
+// created has a free name and fits in the quota of the account, which the
+// API would otherwise only reject during apply.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// There is no client while the provider configuration is unknown.
+	if r.client == nil {
+		return
+	}
+
+	if r.readOnly && !req.Plan.Raw.Equal(req.State.Raw) {
+		switch {
+		case req.Plan.Raw.IsNull():
+			resp.Diagnostics.Append(readOnlyError("destroy environments")...)
+		case req.State.Raw.IsNull():
+			resp.Diagnostics.Append(readOnlyError("create environments")...)
+		default:
+			resp.Diagnostics.Append(readOnlyError("update environments")...)
+		}
+		return
+	}
+
+	var plan, state environmentResourceModel
+	if !req.Plan.Raw.IsNull() {
+		diags := req.Plan.Get(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !req.State.Raw.IsNull() {
+		diags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		r.resolveDefaults(ctx, req.Config, &plan, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	switch {
+	case req.Plan.Raw.IsNull():
+		resp.Diagnostics.Append(r.policy.checkDelete(state)...)
+		return
+	case req.State.Raw.IsNull():
+	case len(resp.RequiresReplace) > 0 || regionReplaced(plan, state):
+		resp.Diagnostics.Append(r.policy.checkDelete(state)...)
+	default:
+		return
+	}
+
+	resp.Diagnostics.Append(r.policy.checkCreate(plan)...)
+	r.preflightCreate(ctx, plan, state, resp)
+}
+
+// regionReplaced reports whether the plan moves the environment to another
+// region, which replaces it. The replacements requested by attribute plan
+// modifiers such as regionChanged are not passed to ModifyPlan.
+func regionReplaced(plan, state environmentResourceModel) bool {
+	if plan.Region.IsUnknown() {
+		return false
+	}
+	return normalizeRegion(plan.Region.ValueString()) != normalizeRegion(state.Region.ValueString())
+}
+
+// resolveDefaults plans the region and full name of the environment from
+// the defaults of the provider. Existing environments keep their region
+// when none is configured, while a changed name prefix replaces them.
+func (r *environmentResource) resolveDefaults(ctx context.Context, config tfsdk.Config, plan *environmentResourceModel, state environmentResourceModel, resp *resource.ModifyPlanResponse) {
+	var configured RegionValue
+	diags := config.GetAttribute(ctx, path.Root("region"), &configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The region of an existing environment is already planned from state.
+	if configured.IsNull() && plan.Region.IsUnknown() {
+		if r.defaults.region == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("region"),
+				"Missing Environment Region",
+				"Set `region` on the environment, or `default_region` on the provider.",
+			)
+			return
+		}
+		plan.Region = NewRegionValue(r.defaults.region)
+	}
+
+	if !plan.Name.IsUnknown() {
+		plan.FullName = types.StringValue(r.defaults.namePrefix + plan.Name.ValueString())
+		if !state.FullName.IsNull() && !plan.FullName.Equal(state.FullName) {
+			resp.RequiresReplace.Append(path.Root("full_name"))
+		}
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("region"), plan.Region)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.Plan.SetAttribute(ctx, path.Root("full_name"), plan.FullName)
+	resp.Diagnostics.Append(diags...)
+}
+
+// preflightCreate runs the name collision and quota checks for a new
+// environment. The environment being replaced, if any, is destroyed first,
+// so it neither collides nor counts towards the quota. Terraform plans the
+// new half of a replacement a second time without prior state, when the
+// environment being replaced cannot be told apart from the others: a name
+// collision and an account exactly at its limit then only get a warning,
+// and Create checks both again once that environment is gone. Environments
+// created by other resources in the same apply are not accounted for.
+func (r *environmentResource) preflightCreate(ctx context.Context, plan, state environmentResourceModel, resp *resource.ModifyPlanResponse) {
+	name := plan.environmentName()
+	replaced := state.environmentName()
+
+	tflog.Debug(ctx, "Running ClearScape Environment preflight checks", map[string]interface{}{"name": name})
+
+	environments, err := r.client.GetEnvironments(ctx)
+	if err != nil && r.policy.maxEnvironments > 0 {
+		// The policy must not be bypassed by an API error.
+		resp.Diagnostics.AddError(
+			"Failed to Check Environment Limit of Provider Policy",
+			fmt.Sprintf("The environments of the account could not be listed to check that %q fits in max_environments: %s", name, err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Skipped ClearScape Environment Preflight Checks",
+			fmt.Sprintf("The environments of the account could not be listed to check that %q can be created: %s", name, err),
+		)
+		return
+	}
+
+	count := 0
+	for _, env := range *environments {
+		if replaced != "" && env.Name == replaced {
+			continue
+		}
+		if !plan.Name.IsUnknown() && env.Name == name {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("name"),
+				"ClearScape Environment Name Already Taken",
+				fmt.Sprintf("An environment named %q already exists in region %s. Unless this resource replaces it, creating %q will fail. "+
+					"Choose another name, or import the existing environment with an import block or `terraform import`.", name, env.Region, name),
+			)
+			continue
+		}
+		count++
+	}
+
+	mayReplace := replaced == ""
+	resp.Diagnostics.Append(r.policy.checkCount(name, count, mayReplace)...)
+
+	quota, err := r.client.GetQuota(ctx)
+	switch {
+	case client.IsNotFound(err):
+		tflog.Debug(ctx, "ClearScape API reports no quota, skipping quota check")
+	case err != nil:
+		resp.Diagnostics.AddWarning(
+			"Skipped ClearScape Environment Quota Check",
+			fmt.Sprintf("The quota of the account could not be read to check that %q can be created: %s", name, err),
+		)
+	case quota.MaxEnvironments > 0 && mayReplace && count == quota.MaxEnvironments:
+		resp.Diagnostics.AddWarning(
+			"ClearScape Environment Quota Reached",
+			fmt.Sprintf("The account already has %d environments and may have at most %d, so %q can only be created if it replaces one of them.", count, quota.MaxEnvironments, name),
+		)
+	case quota.MaxEnvironments > 0 && count >= quota.MaxEnvironments:
+		resp.Diagnostics.AddError(
+			"ClearScape Environment Quota Exceeded",
+			fmt.Sprintf("The account already has %d environments and may have at most %d, so creating %q would fail. "+
+				"Delete environments that are no longer needed, or ask for a higher quota.", count, quota.MaxEnvironments, name),
+		)
+	case quota.MaxEnvironments > 0 && count+1 == quota.MaxEnvironments:
+		resp.Diagnostics.AddWarning(
+			"ClearScape Environment Quota Reached",
+			fmt.Sprintf("Creating %q uses the last of the %d environments the account may have. Creating further environments will fail.", name, quota.MaxEnvironments),
+		)
+	}
+}
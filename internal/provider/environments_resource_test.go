@@ -0,0 +1,908 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testEnvironment is returned by the test API server. Its credentials must
+// never show up in logs.
+func testEnvironment() client.Environment {
+	return client.Environment{
+		Name:    "env1",
+		Region:  "us-central",
+		State:   client.StateRunning,
+		IP:      "10.0.0.1",
+		DNSName: "env1.clearscape.example",
+		Owner:   "jane.doe@example.com",
+		Type:    "demo",
+		Services: []client.Service{
+			{
+				Name: "vantage",
+				URL:  "env1.clearscape.example",
+				Credentials: []client.Credential{
+					{Name: "username", Value: "demo_user"},
+					{Name: "password", Value: "service-password"},
+				},
+			},
+			{
+				Name:        "jupyter",
+				URL:         "https://env1.clearscape.example/lab",
+				Credentials: []client.Credential{{Name: "token", Value: "jupyter-token"}},
+			},
+		},
+	}
+}
+
+// testAPIServer serves the environments of the account, see testAPIHandler.
+func testAPIServer(t *testing.T, environments ...client.Environment) *client.Client {
+	t.Helper()
+
+	return testClient(t, testAPIHandler(environments...))
+}
+
+// testClient returns a client of a test API server answering with handler.
+func testClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(server.URL, "api-token", client.TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// testAPIHandler serves the environments of the account, keyed by name.
+// Unknown environments are not found, created ones are added to the account
+// with the details of testEnvironment, and start, stop and delete take
+// effect immediately.
+func testAPIHandler(environments ...client.Environment) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/environments" {
+			switch r.Method {
+			case http.MethodGet:
+				_ = json.NewEncoder(w).Encode(environments)
+				return
+			case http.MethodPost:
+				var req client.EnvironmentCreateRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				for _, env := range environments {
+					if env.Name == req.Name {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message":"name already taken"}`))
+						return
+					}
+				}
+				env := testEnvironment()
+				env.Name, env.Region = req.Name, req.Region
+				environments = append(environments, env)
+				_ = json.NewEncoder(w).Encode(env)
+				return
+			}
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/environments/")
+		for i := range environments {
+			env := &environments[i]
+			if env.Name != name {
+				continue
+			}
+
+			switch r.Method {
+			case http.MethodPatch:
+				var req client.EnvironmentUpdateRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				switch req.Operation {
+				case client.OperationStart:
+					env.State = client.StateRunning
+				case client.OperationStop:
+					env.State = client.StateStopped
+				}
+			case http.MethodDelete:
+				environments = append(environments[:i], environments[i+1:]...)
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(env)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"not found"}`))
+	}
+}
+
+func testResourceSchemas(t *testing.T) (resource.SchemaResponse, resource.IdentitySchemaResponse) {
+	t.Helper()
+
+	r := &environmentResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, &identityResp)
+	return schemaResp, identityResp
+}
+
+// testPlannedModel returns the plan of a new environment, with the computed
+// attributes unknown, or null when building the configuration.
+func testPlannedModel(name string, region string, password string, computed func() types.String) environmentResourceModel {
+	m := newEnvironmentResourceModel()
+	m.Name = types.StringValue(name)
+	m.Password = types.StringValue(password)
+	m.FullName, m.State, m.IP, m.DNSName, m.Owner, m.Type = computed(), computed(), computed(), computed(), computed(), computed()
+
+	serviceType := types.ObjectType{AttrTypes: environmentServiceModel{}.AttributeTypes()}
+	namedServiceType := types.ObjectType{AttrTypes: environmentNamedServiceModel{}.AttributeTypes()}
+	if computed().IsUnknown() {
+		m.Region = NewRegionUnknown()
+		m.LastUpdated, m.CreatedAt = NewTimestampUnknown(), NewTimestampUnknown()
+		m.Services = types.ListUnknown(serviceType)
+		m.ServicesByName = types.MapUnknown(namedServiceType)
+		m.ConnectionInfo = types.ObjectUnknown(environmentConnectionModel{}.AttributeTypes())
+		m.Operation = types.StringUnknown()
+	} else {
+		m.Region = NewRegionNull()
+		m.Services = types.ListNull(serviceType)
+		m.ServicesByName = types.MapNull(namedServiceType)
+		m.ConnectionInfo = types.ObjectNull(environmentConnectionModel{}.AttributeTypes())
+	}
+	if region != "" {
+		m.Region = NewRegionValue(region)
+	}
+	return m
+}
+
+// testObject converts a model to the raw value of the resource schema.
+func testObject(t *testing.T, schemaResp resource.SchemaResponse, m environmentResourceModel) tftypes.Value {
+	t.Helper()
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)}
+	if diags := state.Set(context.Background(), &m); diags.HasError() {
+		t.Fatalf("building object: %v", diags)
+	}
+	return state.Raw
+}
+
+func testNull(schemaResp resource.SchemaResponse) tftypes.Value {
+	return tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)
+}
+
+func TestEnvironmentResourceCreateReadLogsNoSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_CLEARSCAPE_HTTP", "TRACE")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	r := &environmentResource{client: testAPIServer(t)}
+
+	createResp := testCreate(ctx, t, r, "env1")
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State, Identity: createResp.Identity}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State, Identity: createResp.Identity}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+
+	var state environmentResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.Password.ValueString() != "plan-password" || state.State.ValueString() != client.StateRunning {
+		t.Fatalf("unexpected state after Read: password=%s state=%s", state.Password, state.State)
+	}
+
+	logs := output.String()
+	if !strings.Contains(logs, "ClearScape API response") || !strings.Contains(logs, "Reading ClearScape Environment") {
+		t.Fatalf("expected provider and API logs, got:\n%s", logs)
+	}
+	for _, secret := range []string{"api-token", "plan-password", "service-password", "demo_user", "jupyter-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output contains %q", secret)
+		}
+	}
+}
+
+// testPollInterval makes the client poll environments without delay.
+func testPollInterval(t *testing.T) {
+	pollInterval := client.PollInterval
+	client.PollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { client.PollInterval = pollInterval })
+}
+
+// testStateModel returns the state of an environment managed by Terraform.
+func testStateModel(t *testing.T, env client.Environment, password string) environmentResourceModel {
+	t.Helper()
+
+	m := newEnvironmentResourceModel()
+	m.Name = types.StringValue(env.Name)
+	m.Password = types.StringValue(password)
+	if diags := m.refresh(context.Background(), &env); diags.HasError() {
+		t.Fatalf("refresh: %v", diags)
+	}
+	return m
+}
+
+func TestEnvironmentResourceUpdateRotatesPasswordOfStoppedEnvironment(t *testing.T) {
+	testPollInterval(t)
+
+	env := testEnvironment()
+	env.State = client.StateStopped
+	r := &environmentResource{client: testAPIServer(t, env)}
+	schemaResp, identityResp := testResourceSchemas(t)
+
+	state := testStateModel(t, env, "old-password")
+	plan := state
+	plan.Password = types.StringValue("new-password")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	raw := testObject(t, schemaResp, plan)
+	resp := resource.UpdateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+		Identity: &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil)},
+	}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, state)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+}
+
+func TestEnvironmentResourceCreateAdoptsOnlyOwnedEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner string
+		adopt bool
+	}{
+		{name: "owned", owner: "Jane.Doe@example.com", adopt: true},
+		{name: "owned by someone else", owner: "john.roe@example.com", adopt: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := testEnvironment()
+			env.Owner = tt.owner
+
+			// The environment is created, but the API fails to tell.
+			var created atomic.Bool
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/account":
+					_, _ = w.Write([]byte(`{"email":"jane.doe@example.com"}`))
+				case r.URL.Path == "/environments" && r.Method == http.MethodPost:
+					created.Store(true)
+					w.WriteHeader(http.StatusBadGateway)
+				case !created.Load():
+					w.WriteHeader(http.StatusNotFound)
+				case r.URL.Path == "/environments":
+					_ = json.NewEncoder(w).Encode([]client.Environment{env})
+				default:
+					_ = json.NewEncoder(w).Encode(env)
+				}
+			})
+
+			ctx := context.Background()
+			r := &environmentResource{client: c}
+
+			resp := testCreate(ctx, t, r, "env1")
+
+			if got := !resp.Diagnostics.HasError(); got != tt.adopt {
+				t.Fatalf("adopted = %t, want %t: %v", got, tt.adopt, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEnvironmentResourceReadErrors(t *testing.T) {
+	schemaResp, identityResp := testResourceSchemas(t)
+	state := testStateModel(t, testEnvironment(), "old-password")
+
+	t.Run("not found", func(t *testing.T) {
+		r := &environmentResource{client: testAPIServer(t)}
+		resp := testRead(t, r, schemaResp, identityResp, state)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read: %v", resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Error("Read kept a deleted environment in state")
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		r := &environmentResource{client: c}
+		resp := testRead(t, r, schemaResp, identityResp, state)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("Read ignored an API error")
+		}
+	})
+}
+
+func testRead(t *testing.T, r *environmentResource, schemaResp resource.SchemaResponse, identityResp resource.IdentitySchemaResponse, state environmentResourceModel) resource.ReadResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	current := tfsdk.State{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, state)}
+	identity := &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil)}
+	resp := resource.ReadResponse{State: current, Identity: identity}
+	r.Read(ctx, resource.ReadRequest{State: current, Identity: identity}, &resp)
+	return resp
+}
+
+// testModifyPlan plans the creation of an environment, as Terraform does for
+// a new resource and for the new half of a replacement.
+func testModifyPlan(t *testing.T, r *environmentResource, name string) resource.ModifyPlanResponse {
+	t.Helper()
+
+	planned := testPlannedModel(name, "us-central", "plan-password", types.StringUnknown)
+	configured := testPlannedModel(name, "us-central", "plan-password", types.StringNull)
+	return testModifyPlanChange(t, r, planned, configured, nil)
+}
+
+// testModifyPlanChange plans a change from state, or a creation when state
+// is nil.
+func testModifyPlanChange(t *testing.T, r *environmentResource, planned, configured environmentResourceModel, state *environmentResourceModel) resource.ModifyPlanResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp, _ := testResourceSchemas(t)
+	prior := tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)}
+	if state != nil {
+		prior.Raw = testObject(t, schemaResp, *state)
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, planned)}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, configured)},
+		Plan:   plan,
+		State:  prior,
+	}, &resp)
+	return resp
+}
+
+func TestEnvironmentResourceModifyPlanReplacesSameName(t *testing.T) {
+	// After `terraform taint` or `-replace`, the new environment is planned
+	// without prior state while the tainted one with the same name exists.
+	r := &environmentResource{client: testAPIServer(t, testEnvironment())}
+
+	resp := testModifyPlan(t, r, "env1")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a name warning, got %v", resp.Diagnostics)
+	}
+
+	var plan environmentResourceModel
+	resp.Plan.Get(context.Background(), &plan)
+	if plan.FullName.ValueString() != "env1" {
+		t.Errorf("full_name = %s, want env1", plan.FullName)
+	}
+}
+
+func TestEnvironmentResourceCreateRejectsTakenName(t *testing.T) {
+	ctx := context.Background()
+	r := &environmentResource{client: testAPIServer(t, testEnvironment())}
+
+	resp := testCreate(ctx, t, r, "env1")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "ClearScape Environment Name Already Taken" {
+		t.Fatalf("expected a name error, got %v", resp.Diagnostics)
+	}
+}
+
+// testCreate applies the creation of an environment.
+func testCreate(ctx context.Context, t *testing.T, r *environmentResource, name string) resource.CreateResponse {
+	t.Helper()
+
+	planned := testPlannedModel(name, "us-central", "plan-password", types.StringUnknown)
+	planned.FullName = types.StringValue(name)
+	configured := testPlannedModel(name, "us-central", "plan-password", types.StringNull)
+	return testCreatePlanned(ctx, t, r, planned, configured)
+}
+
+// testCreatePlanned applies the creation of an environment with the given
+// plan and configuration.
+func testCreatePlanned(ctx context.Context, t *testing.T, r *environmentResource, planned, configured environmentResourceModel) resource.CreateResponse {
+	t.Helper()
+
+	schemaResp, identityResp := testResourceSchemas(t)
+	resp := resource.CreateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)},
+		Identity: &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, planned)},
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, configured)},
+	}, &resp)
+	return resp
+}
+
+func TestEnvironmentResourcePolicy(t *testing.T) {
+	ctx := context.Background()
+	schemaResp, _ := testResourceSchemas(t)
+	state := testStateModel(t, testEnvironment(), "plan-password")
+
+	t.Run("region change", func(t *testing.T) {
+		r := &environmentResource{
+			client: testAPIServer(t, testEnvironment()),
+			policy: environmentPolicy{allowedRegions: []string{"us-central"}, forbidDelete: true},
+		}
+
+		// The region changes only in the configuration, which Terraform
+		// copies to the plan before ModifyPlan runs.
+		planned := state
+		planned.Region = NewRegionValue("europe-west")
+		configured := testPlannedModel("env1", "europe-west", "plan-password", types.StringNull)
+
+		resp := testModifyPlanChange(t, r, planned, configured, &state)
+
+		var summaries []string
+		for _, d := range resp.Diagnostics.Errors() {
+			summaries = append(summaries, d.Summary())
+		}
+		want := "Deletion Forbidden by Provider Policy,Region Not Allowed by Provider Policy"
+		if got := strings.Join(summaries, ","); got != want {
+			t.Errorf("errors = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("forbid delete at apply", func(t *testing.T) {
+		r := &environmentResource{
+			client: testAPIServer(t, testEnvironment()),
+			policy: environmentPolicy{forbidDelete: true},
+		}
+
+		current := tfsdk.State{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, state)}
+		resp := resource.DeleteResponse{State: current}
+		r.Delete(ctx, resource.DeleteRequest{State: current}, &resp)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deletion Forbidden by Provider Policy" {
+			t.Fatalf("expected a policy error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("max environments without listing", func(t *testing.T) {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		r := &environmentResource{client: c, policy: environmentPolicy{maxEnvironments: 5}}
+		resp := testModifyPlan(t, r, "env2")
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected an error when the environments cannot be counted, got %v", resp.Diagnostics)
+		}
+
+		r.policy = environmentPolicy{}
+		resp = testModifyPlan(t, r, "env2")
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+			t.Fatalf("expected a warning without max_environments, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestEnvironmentResourceImportStateNamePrefix(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{id: "ci-42-example"},
+		{id: "example", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			ctx := context.Background()
+			r := &environmentResource{defaults: environmentDefaults{namePrefix: "ci-42-"}}
+			schemaResp, _ := testResourceSchemas(t)
+
+			resp := resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)}}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("error = %t, want %t: %v", resp.Diagnostics.HasError(), tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var name types.String
+			resp.State.GetAttribute(ctx, path.Root("name"), &name)
+			if name.ValueString() != "example" {
+				t.Errorf("name = %s, want example", name)
+			}
+		})
+	}
+}
+
+// testDelete applies the destruction of an environment.
+func testDelete(ctx context.Context, t *testing.T, r *environmentResource, state environmentResourceModel) resource.DeleteResponse {
+	t.Helper()
+
+	schemaResp, _ := testResourceSchemas(t)
+	current := tfsdk.State{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, state)}
+	resp := resource.DeleteResponse{State: current}
+	r.Delete(ctx, resource.DeleteRequest{State: current}, &resp)
+	return resp
+}
+
+func TestEnvironmentResourceDelete(t *testing.T) {
+	testPollInterval(t)
+	state := testStateModel(t, testEnvironment(), "plan-password")
+
+	deleting := testEnvironment()
+	deleting.State = "DELETING"
+
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		"deleted": {
+			handler: testAPIHandler(testEnvironment()),
+		},
+		"already gone": {
+			handler: testAPIHandler(),
+		},
+		"already deleting": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"message":"environment env1 is being deleted"}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+		"other conflict": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"message":"another operation is in progress"}`))
+					return
+				}
+				t.Errorf("unexpected %s %s after a rejected delete", r.Method, r.URL.Path)
+			},
+			wantErr: "another operation is in progress",
+		},
+		"timeout": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					_, _ = w.Write([]byte(`{}`))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(deleting)
+			},
+			// The deadline may expire while polling or during a request.
+			wantErr: "context deadline exceeded",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			r := &environmentResource{client: testClient(t, tt.handler)}
+			resp := testDelete(ctx, t, r, state)
+
+			if tt.wantErr == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("Delete: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEnvironmentResourceDeleteModes(t *testing.T) {
+	testPollInterval(t)
+
+	stopped := testEnvironment()
+	stopped.State = client.StateStopped
+
+	tests := map[string]struct {
+		environments       []client.Environment
+		onDestroy          string
+		deletionProtection bool
+		wantErr            string
+		wantState          string
+		wantUnchanged      bool
+	}{
+		"deletion protection": {
+			environments:       []client.Environment{testEnvironment()},
+			onDestroy:          onDestroyDelete,
+			deletionProtection: true,
+			wantErr:            "Cannot Destroy Protected env1 ClearScape Environment",
+			wantState:          client.StateRunning,
+		},
+		"abandon": {
+			environments: []client.Environment{testEnvironment()},
+			onDestroy:    onDestroyAbandon,
+			wantState:    client.StateRunning,
+		},
+		"stop": {
+			environments: []client.Environment{testEnvironment()},
+			onDestroy:    onDestroyStop,
+			wantState:    client.StateStopped,
+		},
+		"stop already stopped": {
+			environments:  []client.Environment{stopped},
+			onDestroy:     onDestroyStop,
+			wantState:     client.StateStopped,
+			wantUnchanged: true,
+		},
+		"stop not found": {
+			onDestroy: onDestroyStop,
+		},
+		"delete": {
+			environments: []client.Environment{testEnvironment()},
+			onDestroy:    onDestroyDelete,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			var patched atomic.Bool
+			api := testAPIHandler(tt.environments...)
+			r := &environmentResource{client: testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					patched.Store(true)
+				}
+				api(w, r)
+			})}
+
+			state := testStateModel(t, testEnvironment(), "plan-password")
+			state.OnDestroy = types.StringValue(tt.onDestroy)
+			state.DeletionProtection = types.BoolValue(tt.deletionProtection)
+
+			resp := testDelete(ctx, t, r, state)
+			if tt.wantErr == "" && resp.Diagnostics.HasError() {
+				t.Fatalf("Delete: %v", resp.Diagnostics)
+			}
+			if tt.wantErr != "" && (!resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.wantErr) {
+				t.Fatalf("expected %q, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantUnchanged && patched.Load() {
+				t.Error("stopped an environment that was already stopped")
+			}
+
+			env, err := r.client.GetEnvironment(ctx, "env1")
+			switch {
+			case tt.wantState == "" && !client.IsNotFound(err):
+				t.Errorf("expected the environment to be gone, got %v, %v", env, err)
+			case tt.wantState != "" && err != nil:
+				t.Errorf("expected the environment to be kept: %s", err)
+			case tt.wantState != "" && env.State != tt.wantState:
+				t.Errorf("state = %s, want %s", env.State, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestEnvironmentResourceCreateSavesStateWhenStopFails(t *testing.T) {
+	testPollInterval(t)
+	ctx := context.Background()
+
+	api := testAPIHandler()
+	r := &environmentResource{client: testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"another operation is in progress"}`))
+			return
+		}
+		api(w, r)
+	})}
+
+	planned := testPlannedModel("env1", "us-central", "plan-password", types.StringUnknown)
+	planned.FullName = types.StringValue("env1")
+	planned.Operation = types.StringValue(client.OperationStop)
+	configured := testPlannedModel("env1", "us-central", "plan-password", types.StringNull)
+	configured.Operation = types.StringValue(client.OperationStop)
+
+	resp := testCreatePlanned(ctx, t, r, planned, configured)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Failed to Stop env1 ClearScape Environment" {
+		t.Fatalf("expected a stop error, got %v", resp.Diagnostics)
+	}
+
+	var state environmentResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("no state saved: %v", diags)
+	}
+	if state.FullName.ValueString() != "env1" || state.State.ValueString() != client.StateRunning {
+		t.Errorf("saved full_name = %s, state = %s", state.FullName, state.State)
+	}
+
+	var identity environmentIdentityModel
+	if diags := resp.Identity.Get(ctx, &identity); diags.HasError() || identity.Name.ValueString() != "env1" {
+		t.Errorf("identity = %+v, %v", identity, diags)
+	}
+}
+
+// testDiagnostics returns the severity and summary of each diagnostic.
+func testDiagnostics(diags diag.Diagnostics) []string {
+	var out []string
+	for _, d := range diags {
+		out = append(out, d.Severity().String()+": "+d.Summary())
+	}
+	return out
+}
+
+func TestEnvironmentResourceRenameAtLimit(t *testing.T) {
+	// The account is full with the environment being renamed, which the
+	// rename replaces.
+	limited := func(environments ...client.Environment) *client.Client {
+		api := testAPIHandler(environments...)
+		return testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/quota" {
+				_, _ = w.Write([]byte(`{"maxEnvironments":1}`))
+				return
+			}
+			api(w, r)
+		})
+	}
+	policy := environmentPolicy{maxEnvironments: 1}
+	state := testStateModel(t, testEnvironment(), "plan-password")
+
+	t.Run("plan with prior state", func(t *testing.T) {
+		r := &environmentResource{client: limited(testEnvironment()), policy: policy}
+
+		planned := state
+		planned.Name = types.StringValue("env2")
+		configured := testPlannedModel("env2", "us-central", "plan-password", types.StringNull)
+
+		resp := testModifyPlanChange(t, r, planned, configured, &state)
+		want := []string{"Warning: ClearScape Environment Quota Reached"}
+		if got := testDiagnostics(resp.Diagnostics); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("diagnostics = %v, want %v", got, want)
+		}
+		if len(resp.RequiresReplace) != 1 {
+			t.Errorf("RequiresReplace = %v, want full_name", resp.RequiresReplace)
+		}
+	})
+
+	t.Run("plan of the new environment", func(t *testing.T) {
+		r := &environmentResource{client: limited(testEnvironment()), policy: policy}
+
+		resp := testModifyPlan(t, r, "env2")
+		want := []string{"Warning: Environment Limit of Provider Policy Reached", "Warning: ClearScape Environment Quota Reached"}
+		if got := testDiagnostics(resp.Diagnostics); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("diagnostics = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("plan beyond the limit", func(t *testing.T) {
+		other := testEnvironment()
+		other.Name = "env3"
+		r := &environmentResource{client: limited(testEnvironment(), other), policy: policy}
+
+		resp := testModifyPlan(t, r, "env2")
+		want := []string{"Error: Environment Limit of Provider Policy Exceeded", "Error: ClearScape Environment Quota Exceeded"}
+		if got := testDiagnostics(resp.Diagnostics); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("diagnostics = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("create after the replaced environment is deleted", func(t *testing.T) {
+		r := &environmentResource{client: limited(), policy: policy}
+
+		resp := testCreate(context.Background(), t, r, "env2")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Create: %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("create while the account is full", func(t *testing.T) {
+		r := &environmentResource{client: limited(testEnvironment()), policy: policy}
+
+		resp := testCreate(context.Background(), t, r, "env2")
+		want := []string{"Error: Environment Limit of Provider Policy Exceeded"}
+		if got := testDiagnostics(resp.Diagnostics); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("diagnostics = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestEnvironmentResourceModifyPlanReadOnly(t *testing.T) {
+	schemaResp, _ := testResourceSchemas(t)
+	state := testStateModel(t, testEnvironment(), "plan-password")
+	configured := testPlannedModel("env1", "us-central", "plan-password", types.StringNull)
+
+	created := testPlannedModel("env1", "us-central", "plan-password", types.StringUnknown)
+	updated := state
+	updated.Password = types.StringValue("new-password")
+
+	tests := map[string]struct {
+		plan    *environmentResourceModel
+		state   *environmentResourceModel
+		wantErr string
+	}{
+		"create": {
+			plan:    &created,
+			wantErr: "cannot create environments",
+		},
+		"update": {
+			plan:    &updated,
+			state:   &state,
+			wantErr: "cannot update environments",
+		},
+		"destroy": {
+			state:   &state,
+			wantErr: "cannot destroy environments",
+		},
+		"unchanged": {
+			plan:  &state,
+			state: &state,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &environmentResource{client: testAPIServer(t, testEnvironment()), readOnly: true}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(t, schemaResp, configured)},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: testNull(schemaResp)},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)},
+			}
+			if tt.plan != nil {
+				req.Plan.Raw = testObject(t, schemaResp, *tt.plan)
+			}
+			if tt.state != nil {
+				req.State.Raw = testObject(t, schemaResp, *tt.state)
+			}
+
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(ctx, req, &resp)
+
+			if tt.wantErr == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("ModifyPlan: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Provider Is Read-Only" ||
+				!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.wantErr) {
+				t.Fatalf("expected a read-only error for %q, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// environmentResourceModelV0 is the state of the environment resource before
+// schema versioning was introduced.
+type environmentResourceModelV0 struct {
+	Name        types.String                `tfsdk:"name"`
+	Region      types.String                `tfsdk:"region"`
+	State       types.String                `tfsdk:"state"`
+	IP          types.String                `tfsdk:"ip"`
+	DNSName     types.String                `tfsdk:"dnsname"`
+	Owner       types.String                `tfsdk:"owner"`
+	Type        types.String                `tfsdk:"type"`
+	LastUpdated types.String                `tfsdk:"last_updated"`
+	Operation   types.String                `tfsdk:"operation"`
+	Password    types.String                `tfsdk:"password"`
+	Services    []environmentServiceModelV0 `tfsdk:"services"`
+}
+
+type environmentServiceModelV0 struct {
+	Name        types.String                   `tfsdk:"name"`
+	URL         types.String                   `tfsdk:"url"`
+	Credentials []environmentCredentialModelV0 `tfsdk:"credentials"`
+}
+
+type environmentCredentialModelV0 struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// UpgradeState migrates state written by earlier schema versions.
+func (r *environmentResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   environmentResourceSchemaV0(),
+			StateUpgrader: upgradeEnvironmentStateV0,
+		},
+	}
+}
+
+func environmentResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":         schema.StringAttribute{Required: true},
+			"last_updated": schema.StringAttribute{Computed: true},
+			"operation":    schema.StringAttribute{Computed: true},
+			"region":       schema.StringAttribute{Required: true},
+			"password":     schema.StringAttribute{Required: true, Sensitive: true},
+			"state":        schema.StringAttribute{Computed: true},
+			"ip":           schema.StringAttribute{Computed: true},
+			"dnsname":      schema.StringAttribute{Computed: true},
+			"owner":        schema.StringAttribute{Computed: true},
+			"type":         schema.StringAttribute{Computed: true},
+			"services": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{Computed: true},
+						"url":  schema.StringAttribute{Computed: true},
+						"credentials": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name":  schema.StringAttribute{Computed: true},
+									"value": schema.StringAttribute{Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// upgradeEnvironmentStateV0 unquotes the password written by the v0 Read,
+// rebuilds the services attributes from the positional v0 lists and sets
+// the defaults of the arguments added since.
+func upgradeEnvironmentStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior environmentResourceModelV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env := &client.Environment{
+		Name:    prior.Name.ValueString(),
+		Region:  prior.Region.ValueString(),
+		State:   prior.State.ValueString(),
+		IP:      prior.IP.ValueString(),
+		DNSName: prior.DNSName.ValueString(),
+		Owner:   prior.Owner.ValueString(),
+		Type:    prior.Type.ValueString(),
+	}
+	for _, service := range prior.Services {
+		s := client.Service{
+			Name: service.Name.ValueString(),
+			URL:  service.URL.ValueString(),
+		}
+		for _, cred := range service.Credentials {
+			s.Credentials = append(s.Credentials, client.Credential{
+				Name:  cred.Name.ValueString(),
+				Value: cred.Value.ValueString(),
+			})
+		}
+		env.Services = append(env.Services, s)
+	}
+
+	upgraded := newEnvironmentResourceModel()
+	upgraded.Operation = prior.Operation
+	upgraded.Password = unquotePassword(prior.Password)
+
+	diags = upgraded.refresh(ctx, env)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &upgraded)
+	resp.Diagnostics.Append(diags...)
+}
+
+// unquotePassword undoes the quoting the v0 Read applied to the password on
+// every refresh, which may have been applied several times.
+func unquotePassword(password types.String) types.String {
+	if password.IsNull() || password.IsUnknown() {
+		return password
+	}
+
+	value := password.ValueString()
+	for strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && len(value) >= 2 {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			break
+		}
+		value = unquoted
+	}
+	return types.StringValue(value)
+}
+
+// nullTimeouts returns an unset timeouts block of the environment resource.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testStateV0 is the state of an environment as written by the v0 schema,
+// with the JSON encoded password and services to be filled in.
+const testStateV0 = `{
+	"name": "env1",
+	"region": "us-central",
+	"state": "RUNNING",
+	"ip": "10.0.0.1",
+	"dnsname": "env1.clearscape.example",
+	"owner": "jane.doe@example.com",
+	"type": "demo",
+	"last_updated": "Monday, 02-Jan-06 15:04:05 MST",
+	"operation": "start",
+	"password": %s,
+	"services": %s
+}`
+
+const testServicesV0 = `[
+	{
+		"name": "vantage",
+		"url": "env1.clearscape.example:1025",
+		"credentials": [
+			{"name": "username", "value": "demo_user"},
+			{"name": "password", "value": "service-password"}
+		]
+	},
+	{
+		"name": "jupyter",
+		"url": "https://env1.clearscape.example/lab",
+		"credentials": [
+			{"name": "token", "value": "jupyter-token"}
+		]
+	}
+]`
+
+func upgradeTestStateV0(t *testing.T, password string, services string) environmentResourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	quoted, err := json.Marshal(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawState := fmt.Sprintf(testStateV0, quoted, services)
+
+	priorSchema := environmentResourceSchemaV0()
+	prior, err := tftypes.ValueFromJSON([]byte(rawState), priorSchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("decoding v0 state: %s", err)
+	}
+
+	schemaResp, _ := testResourceSchemas(t)
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: testNull(schemaResp)},
+	}
+	upgradeEnvironmentStateV0(ctx, resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *priorSchema, Raw: prior},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading v0 state: %v", resp.Diagnostics)
+	}
+
+	var upgraded environmentResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("reading upgraded state: %v", diags)
+	}
+	return upgraded
+}
+
+func TestUpgradeEnvironmentStateV0(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "quoted once", password: strconv.Quote("s3cret")},
+		{name: "quoted several times", password: strconv.Quote(strconv.Quote(strconv.Quote("s3cret")))},
+		{name: "not quoted", password: "s3cret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			upgraded := upgradeTestStateV0(t, tt.password, testServicesV0)
+
+			if got := upgraded.Password.ValueString(); got != "s3cret" {
+				t.Errorf("password = %q, want s3cret", got)
+			}
+			if upgraded.Name.ValueString() != "env1" || upgraded.FullName.ValueString() != "env1" {
+				t.Errorf("name = %s, full_name = %s, want env1", upgraded.Name, upgraded.FullName)
+			}
+			if upgraded.Region.ValueString() != "us-central" || upgraded.Operation.ValueString() != "start" {
+				t.Errorf("region = %s, operation = %s", upgraded.Region, upgraded.Operation)
+			}
+			if upgraded.OnDestroy.ValueString() != onDestroyDelete || upgraded.DeletionProtection.ValueBool() || !upgraded.AdoptExisting.ValueBool() {
+				t.Errorf("unexpected argument defaults: on_destroy = %s, deletion_protection = %s, adopt_existing = %s", upgraded.OnDestroy, upgraded.DeletionProtection, upgraded.AdoptExisting)
+			}
+			if !upgraded.LastUpdated.IsNull() {
+				t.Errorf("last_updated = %s, want null", upgraded.LastUpdated)
+			}
+
+			var services []environmentServiceModel
+			upgraded.Services.ElementsAs(ctx, &services, false)
+			if len(services) != 2 || services[0].Name.ValueString() != "vantage" || services[1].Name.ValueString() != "jupyter" {
+				t.Fatalf("services = %s", upgraded.Services)
+			}
+
+			var byName map[string]environmentNamedServiceModel
+			upgraded.ServicesByName.ElementsAs(ctx, &byName, false)
+			var credentials map[string]string
+			byName["vantage"].Credentials.ElementsAs(ctx, &credentials, false)
+			if credentials["username"] != "demo_user" || credentials["password"] != "service-password" {
+				t.Errorf("services_by_name vantage credentials = %v", credentials)
+			}
+			if byName["jupyter"].URL.ValueString() != "https://env1.clearscape.example/lab" {
+				t.Errorf("services_by_name jupyter = %v", byName["jupyter"])
+			}
+
+			var conn environmentConnectionModel
+			upgraded.ConnectionInfo.As(ctx, &conn, basetypes.ObjectAsOptions{})
+			if conn.Host.ValueString() != "env1.clearscape.example" || conn.Port.ValueInt64() != 1025 ||
+				conn.User.ValueString() != "demo_user" || conn.Password.ValueString() != "service-password" ||
+				conn.JupyterURL.ValueString() != "https://env1.clearscape.example/lab" || conn.JupyterToken.ValueString() != "jupyter-token" {
+				t.Errorf("connection_info = %+v", conn)
+			}
+		})
+	}
+}
+
+func TestUpgradeEnvironmentStateV0NullServices(t *testing.T) {
+	ctx := context.Background()
+	upgraded := upgradeTestStateV0(t, strconv.Quote("s3cret"), "null")
+
+	if upgraded.Password.ValueString() != "s3cret" {
+		t.Errorf("password = %q, want s3cret", upgraded.Password.ValueString())
+	}
+	if upgraded.Services.IsNull() || len(upgraded.Services.Elements()) != 0 {
+		t.Errorf("services = %s, want an empty list", upgraded.Services)
+	}
+	if upgraded.ServicesByName.IsNull() || len(upgraded.ServicesByName.Elements()) != 0 {
+		t.Errorf("services_by_name = %s, want an empty map", upgraded.ServicesByName)
+	}
+
+	var conn environmentConnectionModel
+	upgraded.ConnectionInfo.As(ctx, &conn, basetypes.ObjectAsOptions{})
+	if conn.Host.ValueString() != "env1.clearscape.example" || conn.User.ValueString() != "" || conn.JupyterURL.ValueString() != "" {
+		t.Errorf("connection_info = %+v", conn)
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateUnquotedIdentifier(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		wantErr string
+	}{
+		"simple":                  {name: "sales_2024"},
+		"hash and dollar":         {name: "tmp#orders$v2"},
+		"non-ascii letters":       {name: "Größe_ñandú"},
+		"128 characters":          {name: strings.Repeat("é", 128)},
+		"129 characters":          {name: strings.Repeat("é", 129), wantErr: "at most 128 characters, got 129"},
+		"empty":                   {name: "", wantErr: "must not be empty"},
+		"reserved word uppercase": {name: "SELECT", wantErr: "SELECT is a Teradata reserved word"},
+		"reserved word lowercase": {name: "select", wantErr: "SELECT is a Teradata reserved word"},
+		"leading digit":           {name: "2024_sales", wantErr: "must not start with a digit"},
+		"space":                   {name: "my table", wantErr: "only allowed in quoted names"},
+		"double quote":            {name: `my"table`, wantErr: "only allowed in quoted names"},
+		"trailing spaces":         {name: "orders  ", wantErr: "must not end with spaces"},
+		"control character":       {name: "ord\u0007ers", wantErr: "control character U+0007"},
+		"private use character":   {name: "ord\uE000ers", wantErr: "private use character U+E000"},
+		"invalid utf-8":           {name: "ord\xffers", wantErr: "valid UTF-8"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateUnquotedIdentifier(tt.name)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		"plain":                 {name: "orders", want: `"orders"`},
+		"reserved word":         {name: "select", want: `"select"`},
+		"leading digit":         {name: "2024 sales", want: `"2024 sales"`},
+		"embedded quote":        {name: `my"table`, want: `"my""table"`},
+		"non-ascii letters":     {name: "Größe", want: `"Größe"`},
+		"only spaces":           {name: "   ", wantErr: "must not consist only of spaces"},
+		"trailing spaces":       {name: "orders ", wantErr: "must not end with spaces"},
+		"control character":     {name: "ord\ners", wantErr: "control character U+000A"},
+		"private use character": {name: "ord\uF8FFers", wantErr: "private use character U+F8FF"},
+		"substitute character":  {name: "ord\u001Aers", wantErr: "U+001A"},
+		"129 characters":        {name: strings.Repeat("a", 129), wantErr: "at most 128 characters"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := quoteIdentifier(tt.name)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
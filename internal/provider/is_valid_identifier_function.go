@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &isValidIdentifierFunction{}
+
+func IsValidIdentifierFunction() function.Function {
+	return &isValidIdentifierFunction{}
+}
+
+// isValidIdentifierFunction checks whether a string can be used as an
+// unquoted Teradata object name.
+type isValidIdentifierFunction struct{}
+
+// Metadata returns the function name.
+func (f *isValidIdentifierFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_identifier"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *isValidIdentifierFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether a name is a valid unquoted Teradata object name",
+		MarkdownDescription: "Returns `true` if the name can be used as a Teradata object name without quoting: " +
+			"at most 128 characters of letters, digits, `_`, `#` and `$`, not starting with a digit and not a reserved word. " +
+			"Use `quote_identifier` for names that fail this check.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The database, user, table or other object name to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run checks the name.
+func (f *isValidIdentifierFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	valid := validateUnquotedIdentifier(name) == nil
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, valid))
+}
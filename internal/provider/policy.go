@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-teradata-clearscape/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// providerData is handed by the provider to every resource, data source,
+// ephemeral resource, list resource and action it configures.
+type providerData struct {
+	client   *client.Client
+	policy   environmentPolicy
+	defaults environmentDefaults
+
+	// readOnly forbids every change to environments.
+	readOnly bool
+}
+
+// environmentDefaults holds the settings of the provider configuration
+// applied to every environment resource unless it overrides them.
+type environmentDefaults struct {
+	region     string
+	namePrefix string
+}
+
+// trimNamePrefix returns the name argument of an environment managed with
+// the name prefix of the provider. An environment whose full name lacks
+// the prefix cannot be managed without replacing it.
+func trimNamePrefix(fullName string, namePrefix string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !strings.HasPrefix(fullName, namePrefix) {
+		diags.AddError(
+			"Environment Name Lacks Provider Name Prefix",
+			fmt.Sprintf("The provider configuration sets name_prefix %q, but the environment is named %q. "+
+				"Managing it with this provider configuration would replace it, use a provider configuration without name_prefix instead.", namePrefix, fullName),
+		)
+		return "", diags
+	}
+
+	return strings.TrimPrefix(fullName, namePrefix), diags
+}
+
+// environmentPolicy holds the guardrails set in the provider configuration
+// that every environment managed with it must comply with. The zero value
+// allows everything.
+type environmentPolicy struct {
+	allowedRegions  []string
+	namePattern     *regexp.Regexp
+	maxEnvironments int64
+	forbidDelete    bool
+}
+
+// compileNamePattern compiles the name_pattern provider argument, which has
+// to match the whole environment name.
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// checkCreate reports the policy violations of creating an environment
+// with the planned name and region. Unknown values are not checked.
+func (p environmentPolicy) checkCreate(plan environmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(p.allowedRegions) > 0 && !plan.Region.IsUnknown() && !p.regionAllowed(plan.Region.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("region"),
+			"Region Not Allowed by Provider Policy",
+			fmt.Sprintf("The provider configuration only allows environments in the regions %s, got %q.", strings.Join(p.allowedRegions, ", "), plan.Region.ValueString()),
+		)
+	}
+
+	if p.namePattern != nil && !plan.Name.IsUnknown() && !p.namePattern.MatchString(plan.environmentName()) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Name Not Allowed by Provider Policy",
+			fmt.Sprintf("The provider configuration requires environment names, including the name prefix, to match %s, got %q.", p.namePattern, plan.environmentName()),
+		)
+	}
+
+	return diags
+}
+
+// checkCount reports a violation of max_environments when the account
+// already has count environments and another one is created. When the
+// count may include an environment the new one replaces, an account at the
+// limit only gets a warning, as that environment is deleted first.
+func (p environmentPolicy) checkCount(name string, count int, mayReplace bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch {
+	case p.maxEnvironments <= 0 || int64(count) < p.maxEnvironments:
+	case mayReplace && int64(count) == p.maxEnvironments:
+		diags.AddWarning(
+			"Environment Limit of Provider Policy Reached",
+			fmt.Sprintf("The provider configuration allows at most %d environments and the account already has %d, so %q can only be created if it replaces one of them.", p.maxEnvironments, count, name),
+		)
+	default:
+		diags.AddError(
+			"Environment Limit of Provider Policy Exceeded",
+			fmt.Sprintf("The provider configuration allows at most %d environments and the account already has %d, so %q cannot be created.", p.maxEnvironments, count, name),
+		)
+	}
+
+	return diags
+}
+
+// checkDelete reports a violation of forbid_delete when the environment
+// would be deleted, by a destroy or a replacement. It runs at plan time and
+// again in Delete, which also covers replacements not seen by ModifyPlan.
+func (p environmentPolicy) checkDelete(state environmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p.forbidDelete && state.OnDestroy.ValueString() == onDestroyDelete {
+		diags.AddError(
+			"Deletion Forbidden by Provider Policy",
+			fmt.Sprintf("The provider configuration forbids deleting environments, but %q would be deleted. "+
+				"Set on_destroy to `stop` or `abandon` to remove it from Terraform without deleting it.", state.environmentName()),
+		)
+	}
+
+	return diags
+}
+
+// readOnlyError reports a change planned while the provider is read-only.
+func readOnlyError(change string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("The provider configuration sets read_only, so it cannot %s. Use a provider configuration without read_only to change environments.", change),
+	)
+	return diags
+}
+
+func (p environmentPolicy) regionAllowed(region string) bool {
+	for _, allowed := range p.allowedRegions {
+		if normalizeRegion(allowed) == normalizeRegion(region) {
+			return true
+		}
+	}
+	return false
+}
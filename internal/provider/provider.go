@@ -5,11 +5,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"terraform-provider-teradata-clearscape/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -19,7 +24,14 @@ import (
 )
 
 // Ensure TeradataClearScapeProvider satisfies various provider interfaces.
-var _ provider.Provider = &TeradataClearScapeProvider{}
+var (
+	_ provider.Provider                       = &TeradataClearScapeProvider{}
+	_ provider.ProviderWithEphemeralResources = &TeradataClearScapeProvider{}
+	_ provider.ProviderWithFunctions          = &TeradataClearScapeProvider{}
+	_ provider.ProviderWithListResources      = &TeradataClearScapeProvider{}
+	_ provider.ProviderWithActions            = &TeradataClearScapeProvider{}
+	_ provider.ProviderWithValidateConfig     = &TeradataClearScapeProvider{}
+)
 
 // TeradataClearScapeProvider defines the provider implementation.
 type TeradataClearScapeProvider struct {
@@ -31,7 +43,20 @@ type TeradataClearScapeProvider struct {
 
 // TeradataClearScapeProviderModel describes the provider data model.
 type TeradataClearScapeProviderModel struct {
-	Token types.String `tfsdk:"token"`
+	Token              types.String `tfsdk:"token"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	AllowedRegions     types.List   `tfsdk:"allowed_regions"`
+	NamePattern        types.String `tfsdk:"name_pattern"`
+	MaxEnvironments    types.Int64  `tfsdk:"max_environments"`
+	ForbidDelete       types.Bool   `tfsdk:"forbid_delete"`
+	ReadOnly           types.Bool   `tfsdk:"read_only"`
+	DefaultRegion      types.String `tfsdk:"default_region"`
+	NamePrefix         types.String `tfsdk:"name_prefix"`
 }
 
 func (p *TeradataClearScapeProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,10 +71,105 @@ func (p *TeradataClearScapeProvider) Schema(ctx context.Context, req provider.Sc
 				Optional:  true,
 				Sensitive: true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM bundle of additional certificate authorities to trust, e.g. the CA of a TLS-inspecting proxy.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM bundle of additional certificate authorities to trust.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate, or a path to one, presented to mutual TLS gateways. Requires `client_key`.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key, or a path to one, for `client_cert`.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable verification of the ClearScape API server certificate. Only use this in lab setups.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the HTTP proxy used to reach the ClearScape API. Hosts listed in the `NO_PROXY` environment variable bypass the proxy. Defaults to the `HTTPS_PROXY` environment variable.",
+			},
+			"allowed_regions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Regions environments may be created in. Plans creating an environment in another region fail. Defaults to all regions.",
+			},
+			"name_pattern": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the whole name of every new environment must match, e.g. `team-[a-z]+-.*`.",
+			},
+			"max_environments": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of environments in the account. Plans creating an environment beyond it, or while the environments of the account cannot be listed, fail.",
+			},
+			"forbid_delete": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether deleting an environment, by destroying or replacing it, fails at plan and apply time. Environments with `on_destroy` set to `stop` or `abandon` can still be destroyed.",
+			},
+			"default_region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Region of environments whose `region` is not set. Changing it only affects environments created afterwards.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix added to the `name` of every environment, e.g. to namespace the environments of a CI workspace. Changing it replaces the environments.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether plans creating, updating or destroying environments, or invoking actions on them, fail. Data sources, ephemeral resources and `terraform query` keep working.",
+			},
 		},
 	}
 }
 
+// ValidateConfig checks the policy arguments.
+func (p *TeradataClearScapeProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config TeradataClearScapeProviderModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.NamePattern.IsNull() && !config.NamePattern.IsUnknown() {
+		if _, err := compileNamePattern(config.NamePattern.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_pattern"),
+				"Invalid Name Pattern",
+				fmt.Sprintf("`name_pattern` must be a valid regular expression: %s", err),
+			)
+		}
+	}
+
+	if !config.DefaultRegion.IsNull() && !config.DefaultRegion.IsUnknown() && !config.AllowedRegions.IsNull() && !config.AllowedRegions.IsUnknown() {
+		var policy environmentPolicy
+		diags = config.AllowedRegions.ElementsAs(ctx, &policy.allowedRegions, false)
+		resp.Diagnostics.Append(diags...)
+		if !diags.HasError() && !policy.regionAllowed(config.DefaultRegion.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_region"),
+				"Default Region Not Allowed",
+				fmt.Sprintf("`default_region` must be one of `allowed_regions`, got %q.", config.DefaultRegion.ValueString()),
+			)
+		}
+	}
+
+	if !config.MaxEnvironments.IsNull() && !config.MaxEnvironments.IsUnknown() && config.MaxEnvironments.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_environments"),
+			"Invalid Maximum Number of Environments",
+			fmt.Sprintf("`max_environments` must be at least 1, got %d.", config.MaxEnvironments.ValueInt64()),
+		)
+	}
+}
+
 func (p *TeradataClearScapeProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring ClearScape client")
 	var config TeradataClearScapeProviderModel
@@ -59,11 +179,44 @@ func (p *TeradataClearScapeProvider) Configure(ctx context.Context, req provider
 		return
 	}
 
+	transportUnknown := config.CACertFile.IsUnknown() || config.CACertPEM.IsUnknown() || config.ClientCert.IsUnknown() ||
+		config.ClientKey.IsUnknown() || config.InsecureSkipVerify.IsUnknown() || config.ProxyURL.IsUnknown()
+
+	policyUnknown := config.AllowedRegions.IsUnknown() || config.NamePattern.IsUnknown() ||
+		config.MaxEnvironments.IsUnknown() || config.ForbidDelete.IsUnknown() || config.ReadOnly.IsUnknown() ||
+		config.DefaultRegion.IsUnknown() || config.NamePrefix.IsUnknown()
+
+	// The configuration may depend on resources created in the same apply,
+	// such as the token. Defer everything that needs the client until the
+	// values are known instead of failing, when Terraform supports it.
+	if (transportUnknown || policyUnknown || config.Token.IsUnknown()) && req.ClientCapabilities.DeferralAllowed {
+		tflog.Info(ctx, "Deferring ClearScape client configuration until its values are known")
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
+	if transportUnknown {
+		resp.Diagnostics.AddError(
+			"Unknown ClearScape Transport Configuration",
+			"The provider cannot create the ClearScape API client as the TLS or proxy configuration contains unknown values. ",
+		)
+	}
+
+	if policyUnknown {
+		resp.Diagnostics.AddError(
+			"Unknown ClearScape Policy Configuration",
+			"The provider cannot enforce its policy as allowed_regions, name_pattern, max_environments, forbid_delete, read_only, default_region or name_prefix contains unknown values. ",
+		)
+	}
+
 	if config.Token.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Unknown ClearScape API Token",
-			"The provider cannot create the ClearScape API client as there is an unknown configuration value for the ClearScape API client. ",
+			"The provider cannot create the ClearScape API client as there is an unknown configuration value for the ClearScape API client. "+
+				"Use a Terraform version supporting deferred actions, or apply the resources the token depends on first with -target.",
 		)
 	}
 
@@ -90,17 +243,56 @@ func (p *TeradataClearScapeProvider) Configure(ctx context.Context, req provider
 		return
 	}
 
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "clearscape_token", token)
+	ctx = client.MaskLogContext(ctx, token)
 
 	tflog.Debug(ctx, "Creating ClearScape client")
 
-	client, err := client.NewClient("https://api.clearscape.teradata.com/", token)
+	transport := client.TransportConfig{
+		CACertFile:         config.CACertFile.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		ClientCert:         config.ClientCert.ValueString(),
+		ClientKey:          config.ClientKey.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		ProxyURL:           config.ProxyURL.ValueString(),
+	}
+
+	policy := environmentPolicy{
+		maxEnvironments: config.MaxEnvironments.ValueInt64(),
+		forbidDelete:    config.ForbidDelete.ValueBool(),
+	}
+	diags = config.AllowedRegions.ElementsAs(ctx, &policy.allowedRegions, false)
+	resp.Diagnostics.Append(diags...)
+	if !config.NamePattern.IsNull() {
+		namePattern, err := compileNamePattern(config.NamePattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_pattern"), "Invalid Name Pattern", err.Error())
+		}
+		policy.namePattern = namePattern
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := client.NewClient("https://api.clearscape.teradata.com/", token, transport)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create ClearScape API client", err.Error())
 		return
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+
+	data := &providerData{
+		client: client,
+		policy: policy,
+		defaults: environmentDefaults{
+			region:     config.DefaultRegion.ValueString(),
+			namePrefix: config.NamePrefix.ValueString(),
+		},
+		readOnly: config.ReadOnly.ValueBool(),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
+	resp.ListResourceData = data
+	resp.ActionData = data
 
 	tflog.Info(ctx, "Configured ClearScape client", map[string]any{"success": true})
 
@@ -112,12 +304,40 @@ func (p *TeradataClearScapeProvider) Resources(ctx context.Context) []func() res
 	}
 }
 
+func (p *TeradataClearScapeProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		EnvironmentCredentialsEphemeralResource,
+	}
+}
+
+func (p *TeradataClearScapeProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		EnvironmentListResource,
+	}
+}
+
 func (p *TeradataClearScapeProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		EnvironmentDataSource,
 	}
 }
 
+func (p *TeradataClearScapeProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		EnvironmentStartAction,
+		EnvironmentStopAction,
+		EnvironmentRestartAction,
+	}
+}
+
+func (p *TeradataClearScapeProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		ConnectionStringFunction,
+		QuoteIdentifierFunction,
+		IsValidIdentifierFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &TeradataClearScapeProvider{
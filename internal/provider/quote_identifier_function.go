@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &quoteIdentifierFunction{}
+
+func QuoteIdentifierFunction() function.Function {
+	return &quoteIdentifierFunction{}
+}
+
+// quoteIdentifierFunction quotes a Teradata object name.
+type quoteIdentifierFunction struct{}
+
+// Metadata returns the function name.
+func (f *quoteIdentifierFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quote_identifier"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *quoteIdentifierFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Quote a Teradata object name",
+		MarkdownDescription: "Returns the name as a Teradata quoted identifier, doubling any embedded double quotes, so it can be safely used in SQL. " +
+			"Fails if the name cannot be a Teradata object name even when quoted: empty, longer than 128 characters, " +
+			"ending with spaces, or containing control, private use or unassigned Unicode characters.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The database, user, table or other object name to quote.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run quotes the name.
+func (f *quoteIdentifierFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	quoted, err := quoteIdentifier(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, quoted))
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = RegionType{}
+	_ basetypes.StringValuableWithSemanticEquals = RegionValue{}
+)
+
+// RegionType is a string type for ClearScape regions, whose values compare
+// equal when they only differ in case or separators, e.g. `us-central` and
+// `US Central`.
+type RegionType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t RegionType) String() string {
+	return "RegionType"
+}
+
+// Equal returns true if the given type is equivalent.
+func (t RegionType) Equal(o attr.Type) bool {
+	other, ok := o.(RegionType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueType returns the value type of this type.
+func (t RegionType) ValueType(_ context.Context) attr.Value {
+	return RegionValue{}
+}
+
+// ValueFromString returns a RegionValue given a StringValue.
+func (t RegionType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return RegionValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a RegionValue given a tftypes.Value.
+func (t RegionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return RegionValue{StringValue: stringValue}, nil
+}
+
+// RegionValue is a ClearScape region, see RegionType.
+type RegionValue struct {
+	basetypes.StringValue
+}
+
+func NewRegionValue(value string) RegionValue {
+	return RegionValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewRegionNull() RegionValue {
+	return RegionValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewRegionUnknown() RegionValue {
+	return RegionValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns a RegionType.
+func (v RegionValue) Type(_ context.Context) attr.Type {
+	return RegionType{}
+}
+
+// Equal returns true if the given value is exactly equivalent.
+func (v RegionValue) Equal(o attr.Value) bool {
+	other, ok := o.(RegionValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both regions normalise to the same
+// name, so that the spelling in the configuration is kept in state.
+func (v RegionValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(RegionValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return normalizeRegion(v.ValueString()) == normalizeRegion(newValue.ValueString()), diags
+}
+
+// normalizeRegion returns the canonical form of a region name: lower case,
+// with words separated by single hyphens.
+func normalizeRegion(region string) string {
+	words := strings.FieldsFunc(strings.ToLower(region), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	})
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := map[string]string{
+		"us-central":     "us-central",
+		"US Central":     "us-central",
+		"us_central":     "us-central",
+		"us--central":    "us-central",
+		" Europe_West- ": "europe-west",
+		"":               "",
+	}
+
+	for region, want := range tests {
+		if got := normalizeRegion(region); got != want {
+			t.Errorf("normalizeRegion(%q) = %q, want %q", region, got, want)
+		}
+	}
+}
+
+func TestRegionValueStringSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		old  string
+		new  string
+		want bool
+	}{
+		"identical":       {old: "us-central", new: "us-central", want: true},
+		"case and spaces": {old: "US Central", new: "us-central", want: true},
+		"underscores":     {old: "us_central", new: "us-central", want: true},
+		"double hyphen":   {old: "us--central", new: "us-central", want: true},
+		"other region":    {old: "us-central", new: "europe-west", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := NewRegionValue(tt.old).StringSemanticEquals(context.Background(), NewRegionValue(tt.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("StringSemanticEquals(%q, %q) = %t, want %t", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("other type", func(t *testing.T) {
+		_, diags := NewRegionValue("us-central").StringSemanticEquals(context.Background(), types.StringValue("us-central"))
+		if !diags.HasError() {
+			t.Error("expected an error comparing with a plain string")
+		}
+	})
+}
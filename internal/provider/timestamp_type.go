@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = TimestampType{}
+	_ basetypes.StringValuableWithSemanticEquals = TimestampValue{}
+	_ xattr.ValidateableAttribute                = TimestampValue{}
+)
+
+// TimestampType is a string type for RFC 3339 timestamps, whose values
+// compare equal when they denote the same instant.
+type TimestampType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t TimestampType) String() string {
+	return "TimestampType"
+}
+
+// Equal returns true if the given type is equivalent.
+func (t TimestampType) Equal(o attr.Type) bool {
+	other, ok := o.(TimestampType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueType returns the value type of this type.
+func (t TimestampType) ValueType(_ context.Context) attr.Value {
+	return TimestampValue{}
+}
+
+// ValueFromString returns a TimestampValue given a StringValue.
+func (t TimestampType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return TimestampValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a TimestampValue given a tftypes.Value.
+func (t TimestampType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return TimestampValue{StringValue: stringValue}, nil
+}
+
+// TimestampValue is an RFC 3339 timestamp, see TimestampType.
+type TimestampValue struct {
+	basetypes.StringValue
+}
+
+func NewTimestampValue(t time.Time) TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringValue(t.Format(time.RFC3339Nano))}
+}
+
+func NewTimestampNull() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewTimestampUnknown() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns a TimestampType.
+func (v TimestampValue) Type(_ context.Context) attr.Type {
+	return TimestampType{}
+}
+
+// Equal returns true if the given value is exactly equivalent.
+func (v TimestampValue) Equal(o attr.Value) bool {
+	other, ok := o.(TimestampValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both timestamps denote the same
+// instant, regardless of their time zone offsets and precision.
+func (v TimestampValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TimestampValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	// Validation ensures both parse, compare the strings if they did not.
+	oldTime, err := time.Parse(time.RFC3339, v.ValueString())
+	if err != nil {
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+	newTime, err := time.Parse(time.RFC3339, newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return oldTime.Equal(newTime), diags
+}
+
+// ValidateAttribute checks that the value is an RFC 3339 timestamp.
+func (v TimestampValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid RFC 3339 Timestamp",
+			fmt.Sprintf("A string value was provided that is not a valid RFC 3339 timestamp, e.g. `2025-01-02T15:04:05Z`: %s", err),
+		)
+	}
+}
+
+// ValueTime returns the timestamp as a time.Time.
+func (v TimestampValue) ValueTime() (time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if v.IsNull() || v.IsUnknown() {
+		diags.AddError("Timestamp ValueTime Error", "The timestamp is null or unknown.")
+		return time.Time{}, diags
+	}
+
+	t, err := time.Parse(time.RFC3339, v.ValueString())
+	if err != nil {
+		diags.AddError("Timestamp ValueTime Error", fmt.Sprintf("The timestamp %q is not a valid RFC 3339 timestamp: %s", v.ValueString(), err))
+	}
+	return t, diags
+}
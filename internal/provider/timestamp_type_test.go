@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestTimestampValueStringSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		old  string
+		new  string
+		want bool
+	}{
+		"identical":              {old: "2025-01-02T15:04:05Z", new: "2025-01-02T15:04:05Z", want: true},
+		"other offset":           {old: "2025-01-02T15:04:05Z", new: "2025-01-02T16:04:05+01:00", want: true},
+		"negative offset":        {old: "2025-01-02T15:04:05+00:00", new: "2025-01-02T10:04:05-05:00", want: true},
+		"trailing zero fraction": {old: "2025-01-02T15:04:05Z", new: "2025-01-02T15:04:05.000Z", want: true},
+		"other precision":        {old: "2025-01-02T15:04:05.123Z", new: "2025-01-02T15:04:05.123456789Z", want: false},
+		"other instant":          {old: "2025-01-02T15:04:05Z", new: "2025-01-02T15:04:06Z", want: false},
+		"unparsable identical":   {old: "yesterday", new: "yesterday", want: true},
+		"unparsable different":   {old: "yesterday", new: "today", want: false},
+		"unparsable new value":   {old: "2025-01-02T15:04:05Z", new: "yesterday", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			oldValue := TimestampValue{StringValue: basetypes.NewStringValue(tt.old)}
+			newValue := TimestampValue{StringValue: basetypes.NewStringValue(tt.new)}
+
+			got, diags := oldValue.StringSemanticEquals(context.Background(), newValue)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("StringSemanticEquals(%q, %q) = %t, want %t", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("other type", func(t *testing.T) {
+		_, diags := NewTimestampNull().StringSemanticEquals(context.Background(), types.StringValue("2025-01-02T15:04:05Z"))
+		if !diags.HasError() {
+			t.Error("expected an error comparing with a plain string")
+		}
+	})
+}
+
+func TestTimestampFromAPI(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"utc":          {value: "2025-01-02T15:04:05Z", want: "2025-01-02T15:04:05Z"},
+		"offset":       {value: "2025-01-02T16:04:05.5+01:00", want: "2025-01-02T16:04:05.5+01:00"},
+		"empty":        {value: ""},
+		"not rfc 3339": {value: "Thursday, 02-Jan-25 15:04:05 UTC"},
+		"date only":    {value: "2025-01-02"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := timestampFromAPI(context.Background(), tt.value)
+			if tt.want == "" {
+				if !got.IsNull() {
+					t.Errorf("timestampFromAPI(%q) = %s, want null", tt.value, got)
+				}
+				return
+			}
+			if got.ValueString() != tt.want {
+				t.Errorf("timestampFromAPI(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}